@@ -0,0 +1,36 @@
+// Writes brotli and gzip compressed variants next to all compressible files in dist so that the backend
+// can serve them without compressing on each request.
+import { readdir, readFile, writeFile } from "node:fs/promises"
+import { join, extname } from "node:path"
+import { brotliCompressSync, gzipSync, constants } from "node:zlib"
+
+const dir = process.argv[2] ?? "dist"
+const extensions = new Set([".html", ".js", ".mjs", ".css", ".json", ".webmanifest", ".svg", ".txt", ".map", ".wasm"])
+const minSize = 256
+
+for (const entry of await readdir(dir, { recursive: true, withFileTypes: true })) {
+    if (!entry.isFile() || !extensions.has(extname(entry.name))) {
+        continue
+    }
+
+    const file = join(entry.parentPath ?? entry.path, entry.name)
+    const data = await readFile(file)
+    if (data.length < minSize) {
+        continue
+    }
+
+    const br = brotliCompressSync(data, {
+        params: {
+            [constants.BROTLI_PARAM_QUALITY]: constants.BROTLI_MAX_QUALITY,
+            [constants.BROTLI_PARAM_SIZE_HINT]: data.length,
+        },
+    })
+    if (br.length < data.length) {
+        await writeFile(`${file}.br`, br)
+    }
+
+    const gz = gzipSync(data, { level: constants.Z_BEST_COMPRESSION })
+    if (gz.length < data.length) {
+        await writeFile(`${file}.gz`, gz)
+    }
+}
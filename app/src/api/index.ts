@@ -100,16 +100,24 @@ export class GamemasterApi {
             aspectId: id,
         })
     }
+
+    async createInvite(): Promise<string> {
+        return await this.apiClient.session.createInvite({
+            id: this.sessionId,
+            requestBody: {},
+        })
+    }
 }
 
 export class PlayerCharacterApi {
-    static async joinGame(id: string, name: string): Promise<PlayerCharacterApi> {
+    static async joinGame(id: string, name: string, invite?: string): Promise<PlayerCharacterApi> {
         const apiClient = await createApiClient()
 
         const characterId = await apiClient.session.joinSession({
             id: id,
             requestBody: {
                 name: name,
+                invite: invite,
             }
         })
 
@@ -38,7 +38,11 @@ export class Rejoin {
 export class JoinAsPlayer {
     readonly command = "join-as-player"
 
-    constructor(public readonly id: string, public readonly name: string) { }
+    constructor(public readonly id: string, public readonly name: string, public readonly invite?: string) { }
+}
+
+export class ShareInvite {
+    readonly command = "share-invite"
 }
 
 export class UpdatePlayerFatePoints {
@@ -72,6 +76,7 @@ export type Message = ReplaceScene |
     NewSession |
     Rejoin |
     JoinAsPlayer |
+    ShareInvite |
     UpdatePlayerFatePoints |
     SpendFatePoint |
     AddAspect |
@@ -80,6 +85,7 @@ export type Message = ReplaceScene |
 
 export class Controller {
     private api: GamemasterApi | PlayerCharacterApi | null = null
+    private inviteLink: string | undefined
 
     private updateIntervalHandle: number | null = null
     private inUpdate = false
@@ -89,6 +95,7 @@ export class Controller {
             clearInterval(this.updateIntervalHandle)
         }
         this.inUpdate = false
+        this.inviteLink = undefined
     }
             
     private scheduleUpdates(emit: wecco.MessageEmitter<Message>) {
@@ -113,7 +120,7 @@ export class Controller {
                 let scene: Scene
                 
                 if (this.api instanceof GamemasterApi) {
-                    scene = new GamemasterScene(session)
+                    scene = new GamemasterScene(session, this.inviteLink)
                 } else {
                     scene = new PlayerCharacterScene(session)
                 }
@@ -154,11 +161,25 @@ export class Controller {
 
             case "join-as-player":
                 this.clearUpdate()
-                this.api = await PlayerCharacterApi.joinGame(message.id, message.name)
+                this.api = await PlayerCharacterApi.joinGame(message.id, message.name, message.invite)
                 history.pushState(null, "", `/session/${this.api.sessionId}`)
                 this.scheduleUpdates(emit)
                 break
 
+            case "share-invite":
+                if (this.api instanceof GamemasterApi && model.scene instanceof GamemasterScene) {
+                    const invite = await this.api.createInvite()
+                    this.inviteLink = `${document.location.protocol}//${document.location.host}/join/${this.api.sessionId}/${invite}`
+                    try {
+                        await navigator.clipboard.writeText(this.inviteLink)
+                    } catch (e) {
+                        // The clipboard is not available in insecure contexts; the link is still shown in the scene.
+                        console.log(`Failed to copy invite link to clipboard: ${e}`)
+                    }
+                    return new Model(model.versionInfo, new GamemasterScene(model.scene.session, this.inviteLink), new Notification(m("gamemaster.shareLink.notification")))
+                }
+                break
+
             case "rejoin":
                 this.clearUpdate()
                 try {
@@ -19,9 +19,9 @@ document.addEventListener("DOMContentLoaded", async () => {
     const app = wecco.createApp<Model, Message>(() => new Model(versionInfo, new HomeScene()), controller.update.bind(controller), root).mount("#app")
 
     if (document.location.pathname.startsWith("/join/")) {
-        const sessionId = document.location.pathname.replace("/join/", "")
+        const [sessionId, invite] = document.location.pathname.replace("/join/", "").split("/")
         document.location.hash = ""
-        app.emit(new ReplaceScene(new HomeScene(sessionId)))
+        app.emit(new ReplaceScene(new HomeScene(sessionId, invite)))
 
     } else if (document.location.pathname.startsWith("/session/")) {        
         const sessionId = document.location.pathname.replace("/session/", "")
@@ -57,11 +57,15 @@ export class PlayerCharacterScene {
 export class GamemasterScene {
     constructor(
         public readonly session: Session,
+        public readonly inviteLink?: string,
     ) { }
 }
 
 export class HomeScene {
-    constructor(public readonly joinSessionId?: string) { }
+    constructor(
+        public readonly joinSessionId?: string,
+        public readonly joinInvite?: string,
+    ) { }
 }
 
 export type Scene = HomeScene | PlayerCharacterScene | GamemasterScene
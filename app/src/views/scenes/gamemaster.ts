@@ -1,9 +1,8 @@
 import * as wecco from "@weccoframework/core"
-import { AddAspect, Message, RemoveAspect, UpdatePlayerFatePoints } from "../../control"
+import { AddAspect, Message, RemoveAspect, ShareInvite, UpdatePlayerFatePoints } from "../../control"
 import { Aspect, GamemasterScene, Player, VersionInfo } from "../../models"
 import { m } from "../../utils/i18n"
 import { modal, modalCloseAction } from "../widgets/modal"
-import { showNotification } from "../widgets/notification"
 import { appShell, button, card, container } from "../widgets/ui"
 
 export function gamemaster(versionInfo: VersionInfo, model: GamemasterScene, emit: wecco.MessageEmitter<Message>): wecco.ElementUpdate {
@@ -16,7 +15,7 @@ export function gamemaster(versionInfo: VersionInfo, model: GamemasterScene, emi
         additionalAppBarContent: [
             button({
                 label: wecco.html`<i class="material-icons">share</i>`,
-                onClick: share.bind(undefined, model),
+                onClick: () => emit(new ShareInvite()),
                 testId: "join-session-link"
             }),
         ]
@@ -26,6 +25,7 @@ export function gamemaster(versionInfo: VersionInfo, model: GamemasterScene, emi
 function content(model: GamemasterScene, emit: wecco.MessageEmitter<Message>): wecco.ElementUpdate {
     return wecco.html`<div class="grid grid-cols-1">
         <fcrt-skillcheck></fcrt-skillcheck>
+        ${model.inviteLink ? inviteLink(model.inviteLink) : ""}
 
         <div class="grid grid-cols-1 lg:grid-cols-2 place-content-start">
             ${aspects(emit, model.session.aspects)}
@@ -95,10 +95,11 @@ function fatePoints(fatePoints: number, onChange: (value: number) => void): wecc
     </div>`
 }
 
-function share(model: GamemasterScene) {
-    const url = `${document.location.protocol}//${document.location.host}/join/${model.session.id}`
-    navigator.clipboard.writeText(url)
-    showNotification(m("gamemaster.shareLink.notification"))
+function inviteLink(link: string): wecco.ElementUpdate {
+    return wecco.html`<div class="flex flex-col m-2">
+        <label for="invite-link">${m("gamemaster.inviteLink")}</label>
+        <input id="invite-link" data-testid="invite-link" type="text" readonly value=${link} @focus=${(e: Event) => (e.target as HTMLInputElement).select()}>
+    </div>`
 }
 
 function addAspect(emit: wecco.MessageEmitter<Message>, characterId?: string) {
@@ -11,7 +11,7 @@ export function home(versionInfo: VersionInfo, model: HomeScene, emit: wecco.Mes
             return
         }
 
-        joinSession(emit, model.joinSessionId)
+        joinSession(emit, model.joinSessionId, model.joinInvite)
     }
 
     return appShell({
@@ -71,8 +71,9 @@ function startNewSession(emit: wecco.MessageEmitter<Message>) {
     }).show()
 }
 
-function joinSession(emit: wecco.MessageEmitter<Message>, urlOrId?: string) {
+function joinSession(emit: wecco.MessageEmitter<Message>, urlOrId?: string, invite?: string) {
     let idInput: HTMLInputElement
+    let inviteInput: HTMLInputElement
     let nameInput: HTMLInputElement
 
     const bindIdInput = (e: Event) => {
@@ -82,6 +83,10 @@ function joinSession(emit: wecco.MessageEmitter<Message>, urlOrId?: string) {
         }
     }
 
+    const bindInviteInput = (e: Event) => {
+        inviteInput = e.target as HTMLInputElement
+    }
+
     const bindNameInput = (e: Event) => {
         nameInput = e.target as HTMLInputElement
         if (urlOrId) {
@@ -95,6 +100,9 @@ function joinSession(emit: wecco.MessageEmitter<Message>, urlOrId?: string) {
             <label for="session-id">${m("home.joinSession.promptId")}</label>
             <input id="session-id" data-testid="session-id" type="text" @update=${bindIdInput} value=${urlOrId ?? ""}>
 
+            <label for="invite-code">${m("home.joinSession.promptInvite")}</label>
+            <input id="invite-code" data-testid="invite-code" type="text" @update=${bindInviteInput} value=${invite ?? ""}>
+
             <label for="player-name">${m("home.joinSession.promptName")}</label>
             <input id="player-name" data-testid="player-name" type="text" @update=${bindNameInput}>
         `,
@@ -114,13 +122,19 @@ function joinSession(emit: wecco.MessageEmitter<Message>, urlOrId?: string) {
                     }
 
                     let id: string
-                    if (idOrUrl.indexOf("/") >= 0) {
+                    let inviteCode = inviteInput.value.trim()
+                    if (idOrUrl.indexOf("/join/") >= 0) {
+                        // Join links have the form <origin>/join/<session id>/<invite code>
+                        const [linkId, linkInvite] = idOrUrl.substring(idOrUrl.indexOf("/join/") + "/join/".length).split("/")
+                        id = linkId.trim()
+                        inviteCode = inviteCode || (linkInvite ?? "").trim()
+                    } else if (idOrUrl.indexOf("/") >= 0) {
                         id = idOrUrl.substring(idOrUrl.lastIndexOf("/") + 1).trim()
                     } else {
                         id = idOrUrl.trim()
                     }
                 
-                    m.hide().then(() => emit(new JoinAsPlayer(id, name.trim())))                    
+                    m.hide().then(() => emit(new JoinAsPlayer(id, name.trim(), inviteCode === "" ? undefined : inviteCode)))                    
                 },
             },
             modalCloseAction(),
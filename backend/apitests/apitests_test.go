@@ -4,12 +4,14 @@ package apitests
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/halimath/expect"
 	"github.com/halimath/expect/is"
 	"github.com/halimath/fate-core-remote-table/backend/apitests/httpresponsewith"
 	"github.com/halimath/fate-core-remote-table/backend/internal"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
 	"github.com/halimath/fixture"
 )
 
@@ -38,13 +40,32 @@ func TestAPISceneario(t *testing.T) {
 				httpresponsewith.JSOnBody(r, &session),
 			)
 
+			// Create an invite for the players
+			var inviteID string
+			r, err = gmClient.CreateInvite(f.ctx, sessionID, CreateInvite{})
+			expect.WithMessage(t, "gm: create invite").That(
+				is.NoError(err),
+				expect.FailNow(httpresponsewith.SuccessfulStatusCode(r)),
+				httpresponsewith.TextBody(r, &inviteID),
+			)
+
 			// Create api client for player
 			playerClient := f.AuthorizedAPIClient(t)
 
+			// Joining without an invite must fail
+			r, err = playerClient.JoinSession(f.ctx, sessionID, JoinSession{
+				Name: "Player One",
+			})
+			expect.WithMessage(t, "p1: join session without invite").That(
+				is.NoError(err),
+				is.EqualTo(r.StatusCode, http.StatusForbidden),
+			)
+
 			// Create a PC for that player
 			var pcID string
 			r, err = playerClient.JoinSession(f.ctx, sessionID, JoinSession{
-				Name: "Player One",
+				Name:   "Player One",
+				Invite: &inviteID,
 			})
 			expect.WithMessage(t, "p1: join session").That(
 				is.NoError(err),
@@ -72,7 +93,7 @@ func TestAPISceneario(t *testing.T) {
 								FatePoints: 0,
 							},
 						},
-					}, is.ExcludeFields{"OwnerId"}),
+					}, is.ExcludeFields{"OwnerId", "Members"}),
 				)
 
 			// Increase fate points for player one
@@ -104,8 +125,17 @@ func TestAPISceneario(t *testing.T) {
 								FatePoints: 2,
 							},
 						},
-					}, is.ExcludeFields{"OwnerId"}),
+					}, is.ExcludeFields{"OwnerId", "Members"}),
 				)
+		}).
+		Run("unauthenticated_invalid_body", func(t *testing.T, f *fix) {
+			// Authentication is checked before the request is validated so the response does not disclose
+			// details about the API's structure.
+			r, err := f.apiClient.CreateSessionWithBody(f.ctx, "application/json", strings.NewReader(`{"title": 42}`))
+			expect.That(t,
+				is.NoError(err),
+				is.EqualTo(r.StatusCode, http.StatusUnauthorized),
+			)
 		})
 }
 
@@ -130,13 +160,17 @@ func (f *fix) BeforeAll(t *testing.T) error {
 	f.ctx, f.cancel = context.WithCancel(context.Background())
 	f.done = make(chan struct{})
 
+	t.Setenv("DEV_MODE", "1")
+	cfg, err := config.Load(f.ctx, "")
+	if err != nil {
+		return err
+	}
+
 	go func() {
 		defer close(f.done)
-		t.Setenv("DEV_MODE", "1")
-		internal.RunService(f.ctx)
+		internal.RunService(f.ctx, cfg)
 	}()
 
-	var err error
 	f.apiClient, err = NewClient(server)
 
 	return err
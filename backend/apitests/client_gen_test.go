@@ -33,6 +33,20 @@ const (
 	CreateCharacterTypePC  CreateCharacterType = "PC"
 )
 
+// Defines values for InviteRole.
+const (
+	InviteRolePlayer    InviteRole = "Player"
+	InviteRoleSpectator InviteRole = "Spectator"
+)
+
+// Defines values for MemberRole.
+const (
+	MemberRoleCoGM      MemberRole = "CoGM"
+	MemberRoleOwner     MemberRole = "Owner"
+	MemberRolePlayer    MemberRole = "Player"
+	MemberRoleSpectator MemberRole = "Spectator"
+)
+
 // Aspect defines model for Aspect.
 type Aspect struct {
 	// Id The unique id of the aspect
@@ -88,18 +102,69 @@ type CreateCharacter struct {
 // CreateCharacterType defines model for CreateCharacter.Type.
 type CreateCharacterType string
 
+// CreateInvite defines model for CreateInvite.
+type CreateInvite struct {
+	// ExpiresIn Number of seconds the invite is valid for. If omitted, the invite never expires.
+	ExpiresIn *int `json:"expiresIn,omitempty"`
+
+	// MaxUses Maximum number of joins using this invite. If omitted, the invite can be used any number of times.
+	MaxUses *int `json:"maxUses,omitempty"`
+
+	// Role The role of users using the invite
+	Role *InviteRole `json:"role,omitempty"`
+}
+
 // CreateSession defines model for CreateSession.
 type CreateSession struct {
 	// Title Human readable title of the session
 	Title string `json:"title"`
 }
 
+// Invite defines model for Invite.
+type Invite struct {
+	// Created Creation date of the invite
+	Created time.Time `json:"created"`
+
+	// Expires Expiry date of the invite. Missing if the invite never expires.
+	Expires *time.Time `json:"expires,omitempty"`
+
+	// Id The unique invite code
+	Id string `json:"id"`
+
+	// MaxUses Maximum number of joins using this invite. Missing if the invite can be used any number of times.
+	MaxUses *int `json:"maxUses,omitempty"`
+
+	// Role The role of users using the invite
+	Role InviteRole `json:"role"`
+
+	// Uses Number of joins that used this invite
+	Uses int `json:"uses"`
+}
+
+// InviteRole The role of users using the invite
+type InviteRole string
+
 // JoinSession defines model for JoinSession.
 type JoinSession struct {
+	// Invite The invite code handed out by the game master. Required unless the user is already a member of the session.
+	Invite *string `json:"invite,omitempty"`
+
 	// Name Name of the character joining the session
 	Name string `json:"name"`
 }
 
+// Member defines model for Member.
+type Member struct {
+	// Role The member's role in the session
+	Role MemberRole `json:"role"`
+
+	// UserId The member's user id
+	UserId string `json:"userId"`
+}
+
+// MemberRole The member's role in the session
+type MemberRole string
+
 // ProblemDetails A problem details representation as defined in [RFC9457](https://www.rfc-editor.org/rfc/rfc9457)
 type ProblemDetails struct {
 	// Detail Additional details description
@@ -123,11 +188,14 @@ type ProblemDetails struct {
 
 // Session defines model for Session.
 type Session struct {
+	// Archived Whether the session has been archived. Archived sessions are read-only.
+	Archived   bool        `json:"archived"`
 	Aspects    []Aspect    `json:"aspects"`
 	Characters []Character `json:"characters"`
 
 	// Id The unique id of the session
-	Id string `json:"id"`
+	Id      string   `json:"id"`
+	Members []Member `json:"members"`
 
 	// OwnerId The unique id of the session's owner
 	OwnerId string `json:"ownerId"`
@@ -166,6 +234,9 @@ type CreateCharacterAspectJSONRequestBody = CreateAspect
 // UpdateFatePointsJSONRequestBody defines body for UpdateFatePoints for application/json ContentType.
 type UpdateFatePointsJSONRequestBody = UpdateFatePoints
 
+// CreateInviteJSONRequestBody defines body for CreateInvite for application/json ContentType.
+type CreateInviteJSONRequestBody = CreateInvite
+
 // JoinSessionJSONRequestBody defines body for JoinSession for application/json ContentType.
 type JoinSessionJSONRequestBody = JoinSession
 
@@ -277,6 +348,17 @@ type ClientInterface interface {
 
 	UpdateFatePoints(ctx context.Context, id string, characterId string, body UpdateFatePointsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListInvites request
+	ListInvites(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateInviteWithBody request with any body
+	CreateInviteWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateInvite(ctx context.Context, id string, body CreateInviteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeInvite request
+	RevokeInvite(ctx context.Context, id string, inviteId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// JoinSessionWithBody request with any body
 	JoinSessionWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -442,6 +524,54 @@ func (c *Client) UpdateFatePoints(ctx context.Context, id string, characterId st
 	return c.Client.Do(req)
 }
 
+func (c *Client) ListInvites(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListInvitesRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateInviteWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateInviteRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateInvite(ctx context.Context, id string, body CreateInviteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateInviteRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeInvite(ctx context.Context, id string, inviteId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeInviteRequest(c.Server, id, inviteId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) JoinSessionWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewJoinSessionRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
@@ -843,6 +973,128 @@ func NewUpdateFatePointsRequestWithBody(server string, id string, characterId st
 	return req, nil
 }
 
+// NewListInvitesRequest generates requests for ListInvites
+func NewListInvitesRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions/%s/invites", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateInviteRequest calls the generic CreateInvite builder with application/json body
+func NewCreateInviteRequest(server string, id string, body CreateInviteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateInviteRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewCreateInviteRequestWithBody generates requests for CreateInvite with any type of body
+func NewCreateInviteRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions/%s/invites", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRevokeInviteRequest generates requests for RevokeInvite
+func NewRevokeInviteRequest(server string, id string, inviteId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "inviteId", runtime.ParamLocationPath, inviteId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions/%s/invites/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewJoinSessionRequest calls the generic JoinSession builder with application/json body
 func NewJoinSessionRequest(server string, id string, body JoinSessionJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -10,8 +10,10 @@ import (
 
 	"github.com/halimath/fate-core-remote-table/backend/internal/id"
 	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/metrics"
 	"github.com/halimath/jose/jws"
 	"github.com/halimath/jose/jwt"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type userIDContextKeyType string
@@ -63,6 +65,11 @@ type TokenHandler interface {
 	Authorize(tokenString string) (AuthenticationInfo, error)
 }
 
+var tokensTotal = metrics.Default.NewCounterVec(prometheus.CounterOpts{
+	Name: "fate_table_auth_tokens_total",
+	Help: "Number of authentication token operations by outcome",
+}, []string{"operation", "outcome"})
+
 type jwtTokenHandler struct {
 	signature jws.SignerVerifier
 	tokenTTL  time.Duration
@@ -71,7 +78,9 @@ type jwtTokenHandler struct {
 // CreateToken creates a new token for a new, randomly generated user id. It
 // returns the encoded token.
 func (h *jwtTokenHandler) CreateToken() (string, error) {
-	return h.createToken(id.New())
+	token, err := h.createToken(id.New())
+	countToken("create", err)
+	return token, err
 }
 
 func (h *jwtTokenHandler) createToken(userID string) (string, error) {
@@ -96,10 +105,24 @@ func (h *jwtTokenHandler) createToken(userID string) (string, error) {
 func (h *jwtTokenHandler) RenewToken(tokenString string) (string, error) {
 	n, err := authorize(tokenString, jwt.Signature(h.signature), jwt.Audience(authTokenIssuer), jwt.Issuer(authTokenIssuer), jwt.ExpirationTime(h.tokenTTL))
 	if err != nil {
+		countToken("renew", err)
 		return "", err
 	}
 
-	return h.createToken(n.UserID)
+	token, err := h.createToken(n.UserID)
+	countToken("renew", err)
+	return token, err
+}
+
+func countToken(operation string, err error) {
+	outcome := "ok"
+	if errors.Is(err, ErrUnauthorized) {
+		outcome = "unauthorized"
+	} else if err != nil {
+		outcome = "error"
+	}
+
+	tokensTotal.WithLabelValues(operation, outcome).Inc()
 }
 
 // Authorize authorizes tokenString and returns the encoded user's ID or an error.
@@ -124,6 +147,18 @@ func authorize(tokenString string, verifier ...jwt.Verifier) (AuthenticationInfo
 }
 
 func Provide(cfg config.Config) TokenHandler {
+	return newJWTTokenHandler(cfg)
+}
+
+// CreateTokenFor creates a token for the existing user identified by userID using the secret and TTL from
+// cfg. It allows operators to act on behalf of a user.
+func CreateTokenFor(cfg config.Config, userID string) (string, error) {
+	token, err := newJWTTokenHandler(cfg).createToken(userID)
+	countToken("mint", err)
+	return token, err
+}
+
+func newJWTTokenHandler(cfg config.Config) *jwtTokenHandler {
 	return &jwtTokenHandler{
 		signature: jws.HS256([]byte(cfg.AuthTokenSecret)),
 		tokenTTL:  cfg.AuthTokenTTL,
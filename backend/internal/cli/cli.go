@@ -0,0 +1,158 @@
+// Package cli implements the command line interface of the server binary. Besides serving the application,
+// it provides commands for operators to maintain the sessions kept in a durable repository while the
+// service is stopped.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+	"github.com/halimath/fate-core-remote-table/backend/internal/repository"
+)
+
+// errUsage is returned by commands invoked with invalid arguments.
+var errUsage = errors.New("invalid usage")
+
+// exitCode is returned by commands to exit with a specific code.
+type exitCode int
+
+func (c exitCode) Error() string { return fmt.Sprintf("exit code %d", c) }
+
+// env contains everything passed to a command.
+type env struct {
+	cfg    config.Config
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, e *env, args []string) error
+	// standalone commands run without loading the configuration.
+	standalone bool
+}
+
+var commands = []command{
+	{"serve", "", "run the service (default)", serve, false},
+	{"version", "", "print the version and exit", version, true},
+	{"mint-token", "--user <id>", "create an authentication token for an existing user", mintToken, false},
+	{"list-sessions", "", "list all stored sessions", listSessions, false},
+	{"export", "<session>", "write a session as JSON to stdout", exportSession, false},
+	{"import", "[--replace] <file>", "read a session as JSON from file (- for stdin) and store it", importSession, false},
+	{"gc", "", "expire idle sessions once", collectGarbage, false},
+}
+
+// Run runs the command given by args and returns the process' exit code.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("fate-table", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { usage(flags) }
+
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "path of a YAML configuration file; defaults to $CONFIG_FILE")
+	printConfig := flags.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	name, args := "serve", flags.Args()
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == name {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(stderr, "unknown command: %s\n", name)
+		usage(flags)
+		return 2
+	}
+
+	e := &env{stdin: stdin, stdout: stdout, stderr: stderr}
+
+	if !cmd.standalone || *printConfig {
+		var err error
+		e.cfg, err = config.Load(ctx, *configFile)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	}
+
+	if *printConfig {
+		if err := config.Print(stdout, e.cfg); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		return 0
+	}
+
+	err := cmd.run(ctx, e, args)
+
+	var code exitCode
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &code):
+		return int(code)
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(stderr, "%v\nusage: fate-table [flags] %s %s\n", err, cmd.name, cmd.args)
+		return 2
+	default:
+		fmt.Fprintf(stderr, "%s: %v\n", cmd.name, err)
+		return 1
+	}
+}
+
+func usage(flags *flag.FlagSet) {
+	out := flags.Output()
+	fmt.Fprintln(out, "usage: fate-table [flags] [command] [args]")
+	fmt.Fprintln(out, "\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(out, "  %-32s %s\n", strings.TrimSpace(c.name+" "+c.args), c.summary)
+	}
+	fmt.Fprintln(out, "\nCommands working on sessions require SESSION_STORE=file and must not be run while the")
+	fmt.Fprintln(out, "service is running as it overwrites the session file on shutdown.")
+	fmt.Fprintln(out, "\nflags:")
+	flags.PrintDefaults()
+}
+
+func serve(ctx context.Context, e *env, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: unexpected arguments", errUsage)
+	}
+
+	if code := internal.RunService(ctx, e.cfg); code != 0 {
+		return exitCode(code)
+	}
+	return nil
+}
+
+func version(ctx context.Context, e *env, args []string) error {
+	_, err := fmt.Fprintf(e.stdout, "fate-table %s (%s)\n", internal.Version, internal.Commit)
+	return err
+}
+
+// openStore opens the durable repository configured by e to be used by offline commands.
+func openStore(e *env) (*repository.FileStore, error) {
+	if e.cfg.SessionStore != config.SessionStoreFile {
+		return nil, fmt.Errorf("requires SESSION_STORE=%s; the %q store keeps sessions in memory only",
+			config.SessionStoreFile, e.cfg.SessionStore)
+	}
+
+	return repository.NewFileStore(e.cfg)
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+	"github.com/halimath/fate-core-remote-table/backend/internal/repository"
+)
+
+func run(stdin string, args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = Run(context.Background(), args, strings.NewReader(stdin), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRun_unknownCommand(t *testing.T) {
+	code, _, stderr := run("", "frobnicate")
+	expect.That(t,
+		is.EqualTo(code, 2),
+		is.EqualTo(strings.HasPrefix(stderr, "unknown command: frobnicate\n"), true),
+	)
+}
+
+func TestRun_version(t *testing.T) {
+	code, stdout, _ := run("", "version")
+	expect.That(t,
+		is.EqualTo(code, 0),
+		is.EqualTo(stdout, "fate-table 0.0.0 (local)\n"),
+	)
+}
+
+func TestRun_mintToken(t *testing.T) {
+	t.Setenv("DEV_MODE", "1")
+
+	code, _, _ := run("", "mint-token")
+	expect.That(t, is.EqualTo(code, 2))
+
+	code, stdout, _ := run("", "mint-token", "--user", "42")
+	expect.That(t, is.EqualTo(code, 0))
+
+	cfg, err := config.Load(context.Background(), "")
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	info, err := auth.Provide(cfg).Authorize(strings.TrimSpace(stdout))
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(info.UserID, "42"),
+	)
+}
+
+func TestRun_sessions(t *testing.T) {
+	t.Setenv("SESSION_STORE", "memory")
+	t.Setenv("AUTH_TOKEN_SECRET", "test-secret")
+
+	code, _, stderr := run("", "list-sessions")
+	expect.That(t,
+		is.EqualTo(code, 1),
+		is.EqualTo(strings.Contains(stderr, "requires SESSION_STORE=file"), true),
+	)
+
+	t.Setenv("SESSION_STORE", "file")
+	t.Setenv("SESSION_FILE", filepath.Join(t.TempDir(), "sessions.json"))
+
+	in, _ := json.Marshal(session.Session{ID: "1", OwnerID: "2", Title: "Imported"})
+
+	code, stdout, _ := run(string(in), "import", "-")
+	expect.That(t,
+		is.EqualTo(code, 0),
+		is.EqualTo(stdout, "imported session 1\n"),
+	)
+
+	code, _, stderr = run(string(in), "import", "-")
+	expect.That(t,
+		is.EqualTo(code, 1),
+		is.EqualTo(strings.Contains(stderr, "already exists"), true),
+	)
+
+	code, _, _ = run(string(in), "import", "--replace", "-")
+	expect.That(t, is.EqualTo(code, 0))
+
+	code, stdout, _ = run("", "list-sessions")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	expect.That(t,
+		is.EqualTo(code, 0),
+		is.SliceOfLen(lines, 2),
+		is.EqualTo(strings.HasPrefix(lines[1], "1   2      Imported"), true),
+	)
+
+	code, stdout, _ = run("", "export", "1")
+	var exported session.Session
+	expect.That(t,
+		is.EqualTo(code, 0),
+		is.NoError(json.Unmarshal([]byte(stdout), &exported)),
+		is.EqualTo(exported.Title, "Imported"),
+		is.EqualTo(exported.Version, uint64(2)),
+	)
+
+	code, _, _ = run("", "export", "2")
+	expect.That(t, is.EqualTo(code, 1))
+
+	t.Setenv("SESSION_IDLE_TTL", "1m")
+	code, stdout, _ = run("", "gc")
+	expect.That(t,
+		is.EqualTo(code, 0),
+		is.EqualTo(stdout, "expired 0 sessions (delete)\n"),
+	)
+}
+
+func TestRun_sessionsLocked(t *testing.T) {
+	t.Setenv("SESSION_STORE", "file")
+	t.Setenv("SESSION_FILE", filepath.Join(t.TempDir(), "sessions.json"))
+	t.Setenv("AUTH_TOKEN_SECRET", "test-secret")
+
+	cfg, err := config.Load(context.Background(), "")
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	store, err := repository.NewFileStore(cfg)
+	expect.That(t, expect.FailNow(is.NoError(err)))
+	defer store.Close()
+
+	in, _ := json.Marshal(session.Session{ID: "1", OwnerID: "2", Title: "Imported"})
+
+	code, _, stderr := run(string(in), "import", "-")
+	expect.That(t,
+		is.EqualTo(code, 1),
+		is.EqualTo(strings.Contains(stderr, "in use by another process"), true),
+	)
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+	"github.com/halimath/fate-core-remote-table/backend/internal/janitor"
+	"github.com/halimath/kvlog"
+)
+
+func mintToken(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("mint-token", flag.ContinueOnError)
+	flags.SetOutput(e.stderr)
+	userID := flags.String("user", "", "id of the user the token is created for")
+	if err := flags.Parse(args); err != nil {
+		return exitCode(2)
+	}
+
+	if len(*userID) == 0 || flags.NArg() > 0 {
+		return fmt.Errorf("%w: --user is required", errUsage)
+	}
+
+	token, err := auth.CreateTokenFor(e.cfg, *userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(e.stdout, token)
+	return err
+}
+
+func listSessions(ctx context.Context, e *env, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: unexpected arguments", errUsage)
+	}
+
+	store, err := openStore(e)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	sessions, err := store.List(ctx, func(session.Session) bool { return true })
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tOWNER\tTITLE\tLAST MODIFIED\tARCHIVED")
+	for _, s := range sessions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", s.ID, s.OwnerID, s.Title, s.LastModified.Format(time.RFC3339), s.Archived)
+	}
+	return w.Flush()
+}
+
+func exportSession(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: expected a session id", errUsage)
+	}
+
+	store, err := openStore(e)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var ses session.Session
+	err = store.Perform(ctx, args[0], func(_ context.Context, exists bool, s session.Session) (session.Session, error) {
+		if !exists {
+			return s, usecase.ErrNotFound
+		}
+		ses = s
+		return s, usecase.NoSave
+	})
+	if err != nil {
+		return fmt.Errorf("session %s: %w", args[0], err)
+	}
+
+	enc := json.NewEncoder(e.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(ses)
+}
+
+func importSession(ctx context.Context, e *env, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(e.stderr)
+	replace := flags.Bool("replace", false, "replace an existing session with the same id")
+	if err := flags.Parse(args); err != nil {
+		return exitCode(2)
+	}
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: expected a file name", errUsage)
+	}
+
+	in := e.stdin
+	if name := flags.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	ses, err := readSession(in)
+	if err != nil {
+		return err
+	}
+
+	store, err := openStore(e)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	err = store.Perform(ctx, ses.ID, func(_ context.Context, exists bool, s session.Session) (session.Session, error) {
+		if exists && !*replace {
+			return s, fmt.Errorf("session %s already exists; use --replace to overwrite it", ses.ID)
+		}
+		return ses, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(e.stdout, "imported session %s\n", ses.ID)
+	return err
+}
+
+func readSession(r io.Reader) (session.Session, error) {
+	var ses session.Session
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&ses); err != nil {
+		return ses, fmt.Errorf("invalid session: %w", err)
+	}
+
+	if len(ses.ID) == 0 || len(ses.OwnerID) == 0 {
+		return ses, fmt.Errorf("invalid session: ID and OwnerID are required")
+	}
+
+	return ses, nil
+}
+
+func collectGarbage(ctx context.Context, e *env, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: unexpected arguments", errUsage)
+	}
+
+	if e.cfg.SessionIdleTTL <= 0 {
+		return fmt.Errorf("session expiry is disabled; set SESSION_IDLE_TTL")
+	}
+
+	store, err := openStore(e)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	expired, err := janitor.Provide(e.cfg, kvlog.L, store).Collect(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(e.stdout, "expired %d sessions (%s)\n", expired, e.cfg.SessionExpiryAction)
+	return err
+}
@@ -0,0 +1,41 @@
+package session
+
+import "slices"
+
+// Clone returns a deep copy of s.
+func (s Session) Clone() Session {
+	s.Characters = cloneSlice(s.Characters, Character.clone)
+	s.Invites = slices.Clone(s.Invites)
+	s.Bans = slices.Clone(s.Bans)
+	s.Roles = slices.Clone(s.Roles)
+	s.Aspects = cloneSlice(s.Aspects, Aspect.clone)
+	return s
+}
+
+func (c Character) clone() Character {
+	c.Visibility = c.Visibility.clone()
+	c.Aspects = cloneSlice(c.Aspects, Aspect.clone)
+	return c
+}
+
+func (a Aspect) clone() Aspect {
+	a.Visibility = a.Visibility.clone()
+	return a
+}
+
+func (v Visibility) clone() Visibility {
+	v.CharacterIDs = slices.Clone(v.CharacterIDs)
+	return v
+}
+
+func cloneSlice[S ~[]E, E any](s S, clone func(E) E) S {
+	if s == nil {
+		return nil
+	}
+
+	c := make(S, len(s))
+	for i := range s {
+		c[i] = clone(s[i])
+	}
+	return c
+}
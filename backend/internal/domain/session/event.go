@@ -0,0 +1,335 @@
+package session
+
+import (
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Event is a domain event describing a single change to a session. Applying all events recorded for a
+// session in order rebuilds the session's state.
+type Event interface {
+	// Type returns the event's name.
+	Type() string
+	apply(s *Session)
+}
+
+// Record is a single event recorded in a session's event stream. All events recorded by the same change
+// share the session version they produced.
+type Record struct {
+	Version uint64
+	Time    time.Time
+	// Actor is the id of the user who caused the event. It is empty for changes not caused by a user.
+	Actor string
+	Event Event
+}
+
+// SessionCreated records the creation of a session with its initial state.
+type SessionCreated struct {
+	Session Session
+}
+
+// SessionReplaced records a change that cannot be expressed by more specific events by replacing the whole
+// session's state.
+type SessionReplaced struct {
+	Session Session
+}
+
+// SessionRenamed records a change of the session's title.
+type SessionRenamed struct {
+	Title string
+}
+
+// SessionArchived records archiving or restoring a session.
+type SessionArchived struct {
+	Archived bool
+}
+
+// CharacterJoined records a character added to the session.
+type CharacterJoined struct {
+	Character Character
+}
+
+// CharacterRemoved records a character removed from the session.
+type CharacterRemoved struct {
+	CharacterID string
+}
+
+// FatePointsChanged records setting a character's fate points.
+type FatePointsChanged struct {
+	CharacterID string
+	FatePoints  int
+}
+
+// CharacterVisibilityChanged records a change of the visibility of a character.
+type CharacterVisibilityChanged struct {
+	CharacterID string
+	Visibility  Visibility
+}
+
+// AspectCreated records an aspect added to the session or, if CharacterID is not empty, to a character.
+type AspectCreated struct {
+	CharacterID string
+	Aspect      Aspect
+}
+
+// AspectRemoved records an aspect removed from the session or, if CharacterID is not empty, from a character.
+type AspectRemoved struct {
+	CharacterID string
+	AspectID    string
+}
+
+// AspectVisibilityChanged records a change of the visibility of an aspect.
+type AspectVisibilityChanged struct {
+	AspectID   string
+	Visibility Visibility
+}
+
+// InviteCreated records an invite added to the session.
+type InviteCreated struct {
+	Invite Invite
+}
+
+// InviteUsed records the number of times an invite has been used.
+type InviteUsed struct {
+	InviteID string
+	Uses     int
+}
+
+// InviteRevoked records an invite removed from the session.
+type InviteRevoked struct {
+	InviteID string
+}
+
+// UserBanned records a user banned from the session.
+type UserBanned struct {
+	Ban Ban
+}
+
+// BanLifted records lifting a user's ban.
+type BanLifted struct {
+	UserID string
+}
+
+// RoleAssigned records a role explicitly assigned to a user.
+type RoleAssigned struct {
+	Assignment RoleAssignment
+}
+
+// RoleRevoked records removing the role explicitly assigned to a user.
+type RoleRevoked struct {
+	UserID string
+}
+
+func (SessionCreated) Type() string             { return "SessionCreated" }
+func (SessionReplaced) Type() string            { return "SessionReplaced" }
+func (SessionRenamed) Type() string             { return "SessionRenamed" }
+func (SessionArchived) Type() string            { return "SessionArchived" }
+func (CharacterJoined) Type() string            { return "CharacterJoined" }
+func (CharacterRemoved) Type() string           { return "CharacterRemoved" }
+func (FatePointsChanged) Type() string          { return "FatePointsChanged" }
+func (CharacterVisibilityChanged) Type() string { return "CharacterVisibilityChanged" }
+func (AspectCreated) Type() string              { return "AspectCreated" }
+func (AspectRemoved) Type() string              { return "AspectRemoved" }
+func (AspectVisibilityChanged) Type() string    { return "AspectVisibilityChanged" }
+func (InviteCreated) Type() string              { return "InviteCreated" }
+func (InviteUsed) Type() string                 { return "InviteUsed" }
+func (InviteRevoked) Type() string              { return "InviteRevoked" }
+func (UserBanned) Type() string                 { return "UserBanned" }
+func (BanLifted) Type() string                  { return "BanLifted" }
+func (RoleAssigned) Type() string               { return "RoleAssigned" }
+func (RoleRevoked) Type() string                { return "RoleRevoked" }
+
+func (e SessionCreated) apply(s *Session)  { *s = e.Session.Clone() }
+func (e SessionReplaced) apply(s *Session) { *s = e.Session.Clone() }
+func (e SessionRenamed) apply(s *Session)  { s.Title = e.Title }
+func (e SessionArchived) apply(s *Session) { s.Archived = e.Archived }
+
+func (e CharacterJoined) apply(s *Session) {
+	s.Characters = append(s.Characters, e.Character.clone())
+}
+
+func (e CharacterRemoved) apply(s *Session) { s.RemoveCharacter(e.CharacterID) }
+
+func (e FatePointsChanged) apply(s *Session) {
+	if c := s.FindCharacter(e.CharacterID); c != nil {
+		c.FatePoints = e.FatePoints
+	}
+}
+
+func (e CharacterVisibilityChanged) apply(s *Session) {
+	if c := s.FindCharacter(e.CharacterID); c != nil {
+		c.Visibility = e.Visibility.clone()
+	}
+}
+
+func (e AspectCreated) apply(s *Session) {
+	if aspects := s.aspectsOf(e.CharacterID); aspects != nil {
+		*aspects = append(*aspects, e.Aspect.clone())
+	}
+}
+
+func (e AspectRemoved) apply(s *Session) {
+	if aspects := s.aspectsOf(e.CharacterID); aspects != nil {
+		aspects.RemoveAspect(e.AspectID)
+	}
+}
+
+func (e AspectVisibilityChanged) apply(s *Session) {
+	if a := s.FindAspect(e.AspectID); a != nil {
+		a.Visibility = e.Visibility.clone()
+	}
+}
+
+func (e InviteCreated) apply(s *Session) { s.Invites = append(s.Invites, e.Invite) }
+
+func (e InviteUsed) apply(s *Session) {
+	for i := range s.Invites {
+		if s.Invites[i].ID == e.InviteID {
+			s.Invites[i].Uses = e.Uses
+		}
+	}
+}
+
+func (e InviteRevoked) apply(s *Session) { s.RevokeInvite(e.InviteID) }
+func (e UserBanned) apply(s *Session)    { s.Bans = append(s.Bans, e.Ban) }
+func (e BanLifted) apply(s *Session)     { s.LiftBan(e.UserID) }
+
+func (e RoleAssigned) apply(s *Session) {
+	removeByID(&s.Roles, e.Assignment.UserID)
+	s.Roles = append(s.Roles, e.Assignment)
+}
+
+func (e RoleRevoked) apply(s *Session) { removeByID(&s.Roles, e.UserID) }
+
+// Apply applies events to s in order.
+func (s *Session) Apply(events ...Event) {
+	for _, e := range events {
+		e.apply(s)
+	}
+}
+
+// aspectsOf returns the aspects of the character identified by characterID or the session's aspects if
+// characterID is empty. It returns nil if no such character exists.
+func (s *Session) aspectsOf(characterID string) *Aspects {
+	if len(characterID) == 0 {
+		return &s.Aspects
+	}
+
+	if c := s.FindCharacter(characterID); c != nil {
+		return &c.Aspects
+	}
+
+	return nil
+}
+
+// Changes returns the events that turn before into after. LastModified and Version are not considered. If
+// the difference cannot be expressed with specific events, Changes returns a single SessionReplaced event.
+func Changes(before, after Session) []Event {
+	var events []Event
+
+	if before.Title != after.Title {
+		events = append(events, SessionRenamed{Title: after.Title})
+	}
+
+	if before.Archived != after.Archived {
+		events = append(events, SessionArchived{Archived: after.Archived})
+	}
+
+	for _, c := range before.Characters {
+		if after.FindCharacter(c.ID) == nil {
+			events = append(events, CharacterRemoved{CharacterID: c.ID})
+		}
+	}
+
+	for _, c := range after.Characters {
+		b := before.FindCharacter(c.ID)
+		if b == nil {
+			events = append(events, CharacterJoined{Character: c.clone()})
+			continue
+		}
+
+		if b.FatePoints != c.FatePoints {
+			events = append(events, FatePointsChanged{CharacterID: c.ID, FatePoints: c.FatePoints})
+		}
+
+		if !reflect.DeepEqual(b.Visibility, c.Visibility) {
+			events = append(events, CharacterVisibilityChanged{CharacterID: c.ID, Visibility: c.Visibility.clone()})
+		}
+
+		events = append(events, aspectChanges(c.ID, b.Aspects, c.Aspects)...)
+	}
+
+	events = append(events, aspectChanges("", before.Aspects, after.Aspects)...)
+
+	for _, i := range before.Invites {
+		if !slices.ContainsFunc(after.Invites, func(a Invite) bool { return a.ID == i.ID }) {
+			events = append(events, InviteRevoked{InviteID: i.ID})
+		}
+	}
+
+	for _, i := range after.Invites {
+		idx := slices.IndexFunc(before.Invites, func(b Invite) bool { return b.ID == i.ID })
+		if idx < 0 {
+			events = append(events, InviteCreated{Invite: i})
+		} else if before.Invites[idx].Uses != i.Uses {
+			events = append(events, InviteUsed{InviteID: i.ID, Uses: i.Uses})
+		}
+	}
+
+	for _, b := range before.Bans {
+		if !after.IsBanned(b.UserID) {
+			events = append(events, BanLifted{UserID: b.UserID})
+		}
+	}
+
+	for _, b := range after.Bans {
+		if !before.IsBanned(b.UserID) {
+			events = append(events, UserBanned{Ban: b})
+		}
+	}
+
+	for _, r := range before.Roles {
+		if !slices.ContainsFunc(after.Roles, func(a RoleAssignment) bool { return a.UserID == r.UserID }) {
+			events = append(events, RoleRevoked{UserID: r.UserID})
+		}
+	}
+
+	for _, r := range after.Roles {
+		if !slices.Contains(before.Roles, r) {
+			events = append(events, RoleAssigned{Assignment: r})
+		}
+	}
+
+	// Verify that the events reproduce after exactly. Any difference not covered above, such as changed
+	// ordering, falls back to replacing the whole state.
+	replayed := before.Clone()
+	replayed.Apply(events...)
+	replayed.LastModified, replayed.Version = after.LastModified, after.Version
+	if !reflect.DeepEqual(replayed, after) {
+		return []Event{SessionReplaced{Session: after.Clone()}}
+	}
+
+	return events
+}
+
+func aspectChanges(characterID string, before, after Aspects) []Event {
+	var events []Event
+
+	for _, a := range before {
+		if !slices.ContainsFunc(after, func(b Aspect) bool { return a.ID == b.ID }) {
+			events = append(events, AspectRemoved{CharacterID: characterID, AspectID: a.ID})
+		}
+	}
+
+	for _, a := range after {
+		idx := slices.IndexFunc(before, func(b Aspect) bool { return a.ID == b.ID })
+		if idx < 0 {
+			events = append(events, AspectCreated{CharacterID: characterID, Aspect: a.clone()})
+		} else if !reflect.DeepEqual(before[idx].Visibility, a.Visibility) {
+			events = append(events, AspectVisibilityChanged{AspectID: a.ID, Visibility: a.Visibility.clone()})
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,83 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestChanges(t *testing.T) {
+	before := New("1", "owner", "test")
+	c := before.AddCharacter("player", PC, "Hero")
+	c.AddAspect("Brave")
+	before.AddCharacter("owner", NPC, "Villain")
+	before.AddAspect("Dark night")
+	before.CreateInvite(Player, time.Time{}, 0)
+	before.SetRole("gm", CoGM)
+
+	type test struct {
+		name   string
+		change func(s *Session)
+		want   []string
+	}
+
+	tests := []test{
+		{"none", func(s *Session) {}, nil},
+		{"rename", func(s *Session) { s.Title = "renamed" }, []string{"SessionRenamed"}},
+		{"archive", func(s *Session) { s.Archived = true }, []string{"SessionArchived"}},
+		{"join", func(s *Session) { s.AddCharacter("other", PC, "Sidekick") }, []string{"CharacterJoined"}},
+		{"kick", func(s *Session) { s.RemovePlayer("player") }, []string{"CharacterRemoved"}},
+		{"fate_points", func(s *Session) { s.Characters[0].FatePoints = 3 }, []string{"FatePointsChanged"}},
+		{"character_visibility", func(s *Session) { s.Characters[1].Visibility = Visibility{Level: GMOnly} }, []string{"CharacterVisibilityChanged"}},
+		{"aspect", func(s *Session) { s.AddAspect("Storm") }, []string{"AspectCreated"}},
+		{"character_aspect", func(s *Session) { s.Characters[0].AddAspect("Strong") }, []string{"AspectCreated"}},
+		{"remove_aspect", func(s *Session) { s.Characters[0].RemoveAspect(s.Characters[0].Aspects[0].ID) }, []string{"AspectRemoved"}},
+		{"aspect_visibility", func(s *Session) {
+			s.Aspects[0].Visibility = Visibility{Level: SelectedCharacters, CharacterIDs: []string{s.Characters[0].ID}}
+		}, []string{"AspectVisibilityChanged"}},
+		{"invite", func(s *Session) { s.CreateInvite(Spectator, time.Time{}, 1) }, []string{"InviteCreated"}},
+		{"use_invite", func(s *Session) { s.UseInvite(s.Invites[0].ID, Player, time.Now()) }, []string{"InviteUsed"}},
+		{"revoke_invite", func(s *Session) { s.RevokeInvite(s.Invites[0].ID) }, []string{"InviteRevoked"}},
+		{"ban", func(s *Session) { s.RemovePlayer("player"); s.Ban("player") }, []string{"CharacterRemoved", "UserBanned"}},
+		{"role", func(s *Session) { s.SetRole("gm", Spectator) }, []string{"RoleAssigned"}},
+		{"revoke_role", func(s *Session) { s.SetRole("gm", NoRole) }, []string{"RoleRevoked"}},
+		{"rename_character", func(s *Session) { s.Characters[0].Name = "Renamed" }, []string{"SessionReplaced"}},
+	}
+
+	for _, test := range tests {
+		after := before.Clone()
+		test.change(&after)
+
+		events := Changes(before, after)
+
+		var types []string
+		for _, e := range events {
+			types = append(types, e.Type())
+		}
+
+		replayed := before.Clone()
+		replayed.Apply(events...)
+
+		expect.WithMessage(t, test.name).That(
+			is.DeepEqualTo(types, test.want),
+			is.DeepEqualTo(replayed, after),
+		)
+	}
+}
+
+func TestSession_Clone(t *testing.T) {
+	s := New("1", "owner", "test")
+	c := s.AddCharacter("player", PC, "Hero")
+	c.AddAspect("Brave")
+
+	clone := s.Clone()
+	clone.Characters[0].Aspects[0].Name = "Coward"
+	clone.Characters[0].FatePoints = 2
+
+	expect.That(t,
+		is.EqualTo(s.Characters[0].Aspects[0].Name, "Brave"),
+		is.EqualTo(s.Characters[0].FatePoints, 0),
+	)
+}
@@ -0,0 +1,155 @@
+//go:generate stringer -type=Role -output role_gen.go
+package session
+
+import "slices"
+
+// Role defines the role a user has in a session. Roles are ordered: every role includes the permissions of
+// all roles with a lower value.
+type Role int
+
+const (
+	// NoRole is the role of users that are not a member of the session (or have been banned).
+	NoRole Role = iota
+	// Spectator is the role of users that may watch the session but not take part in it.
+	Spectator
+	// Player is the role of users owning a character in the session.
+	Player
+	// CoGM is the role of users that assist the owner in game mastering the session.
+	CoGM
+	// Owner is the role of the user who created the session.
+	Owner
+)
+
+// Action defines an operation on a session that is subject to authorization.
+type Action int
+
+const (
+	ViewSession Action = iota
+	ViewHiddenData
+	SpendFatePoint
+	EditAspects
+	EditCharacters
+	ManageInvites
+	ManagePlayers
+	ManageRoles
+	EditSession
+	ManageSession
+)
+
+// policy defines the minimum role a user needs to perform an action.
+var policy = map[Action]Role{
+	ViewSession:    Spectator,
+	ViewHiddenData: CoGM,
+	SpendFatePoint: Player,
+	EditAspects:    CoGM,
+	EditCharacters: CoGM,
+	ManageInvites:  CoGM,
+	ManagePlayers:  CoGM,
+	ManageRoles:    Owner,
+	EditSession:    CoGM,
+	ManageSession:  Owner,
+}
+
+// permittedWhenArchived lists the actions that may be performed on archived sessions.
+var permittedWhenArchived = []Action{ViewSession, ViewHiddenData, ManageSession}
+
+// RoleAssignment assigns a role to a user.
+type RoleAssignment struct {
+	UserID string
+	Role   Role
+}
+
+func (r RoleAssignment) id() string {
+	return r.UserID
+}
+
+// Role returns the role of userID in s.
+func (s *Session) Role(userID string) Role {
+	if len(userID) == 0 || s.IsBanned(userID) {
+		return NoRole
+	}
+
+	if s.OwnerID == userID {
+		return Owner
+	}
+
+	for _, r := range s.Roles {
+		if r.UserID == userID {
+			return r.Role
+		}
+	}
+
+	if s.ownsCharacter(userID) {
+		return Player
+	}
+
+	return NoRole
+}
+
+// SetRole explicitly assigns role to userID. Assigning NoRole, or Player to a user owning a character,
+// removes any explicitly assigned role, so the user's role is derived from the characters the user owns.
+// Player is assigned explicitly to users without a character to keep them a member. The owner's role cannot
+// be changed.
+func (s *Session) SetRole(userID string, role Role) {
+	if userID == s.OwnerID {
+		return
+	}
+
+	removeByID(&s.Roles, userID)
+
+	if role == NoRole || (role == Player && s.ownsCharacter(userID)) {
+		return
+	}
+
+	s.Roles = append(s.Roles, RoleAssignment{
+		UserID: userID,
+		Role:   role,
+	})
+}
+
+// ownsCharacter returns true if userID owns at least one character of s.
+func (s *Session) ownsCharacter(userID string) bool {
+	return slices.ContainsFunc(s.Characters, func(c Character) bool { return c.OwnerID == userID })
+}
+
+// Members returns the role assignments for all members of s, starting with the owner.
+func (s *Session) Members() []RoleAssignment {
+	members := []RoleAssignment{{UserID: s.OwnerID, Role: Owner}}
+
+	add := func(userID string) {
+		if slices.ContainsFunc(members, func(r RoleAssignment) bool { return r.UserID == userID }) {
+			return
+		}
+
+		if role := s.Role(userID); role != NoRole {
+			members = append(members, RoleAssignment{UserID: userID, Role: role})
+		}
+	}
+
+	for _, r := range s.Roles {
+		add(r.UserID)
+	}
+
+	for _, c := range s.Characters {
+		add(c.OwnerID)
+	}
+
+	return members
+}
+
+// Allows returns true if a may be performed on s regardless of the user's role. Archived sessions only allow
+// reading and managing the session.
+func (s *Session) Allows(a Action) bool {
+	return !s.Archived || slices.Contains(permittedWhenArchived, a)
+}
+
+// Can returns true if userID is permitted to perform a on s. It only considers userID's role; use Allows to
+// check whether s permits a at all.
+func (s *Session) Can(userID string, a Action) bool {
+	required, ok := policy[a]
+	if !ok {
+		return false
+	}
+
+	return s.Role(userID) >= required
+}
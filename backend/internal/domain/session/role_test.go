@@ -0,0 +1,144 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestSession_Role(t *testing.T) {
+	s := Session{
+		OwnerID: "1",
+		Characters: []Character{
+			{OwnerID: "2"},
+			{OwnerID: "3"},
+		},
+		Roles: []RoleAssignment{
+			{UserID: "3", Role: CoGM},
+			{UserID: "4", Role: Spectator},
+		},
+		Bans: []Ban{
+			{UserID: "5"},
+		},
+	}
+
+	tests := map[string]Role{
+		"1": Owner,
+		"2": Player,
+		"3": CoGM,
+		"4": Spectator,
+		"5": NoRole,
+		"6": NoRole,
+		"":  NoRole,
+	}
+
+	for in, want := range tests {
+		expect.WithMessage(t, "id: %q", in).That(is.EqualTo(s.Role(in), want))
+	}
+}
+
+func TestSession_SetRole(t *testing.T) {
+	s := Session{
+		OwnerID: "1",
+		Characters: []Character{
+			{OwnerID: "2"},
+		},
+	}
+
+	s.SetRole("2", CoGM)
+	expect.That(t, is.EqualTo(s.Role("2"), CoGM))
+
+	s.SetRole("2", Player)
+	expect.That(t,
+		is.EqualTo(s.Role("2"), Player),
+		is.SliceOfLen(s.Roles, 0),
+	)
+
+	s.SetRole("3", CoGM)
+	s.SetRole("3", Player)
+	expect.That(t,
+		is.EqualTo(s.Role("3"), Player),
+		is.DeepEqualTo(s.Roles, []RoleAssignment{{UserID: "3", Role: Player}}),
+	)
+
+	s.SetRole("1", Spectator)
+	expect.That(t, is.EqualTo(s.Role("1"), Owner))
+}
+
+func TestSession_Members(t *testing.T) {
+	s := Session{
+		OwnerID: "1",
+		Characters: []Character{
+			{OwnerID: "2"},
+			{OwnerID: "3"},
+			{OwnerID: "2"},
+			{OwnerID: "1", Type: NPC},
+		},
+		Roles: []RoleAssignment{
+			{UserID: "3", Role: CoGM},
+		},
+	}
+
+	expect.That(t, is.DeepEqualTo(s.Members(), []RoleAssignment{
+		{UserID: "1", Role: Owner},
+		{UserID: "3", Role: CoGM},
+		{UserID: "2", Role: Player},
+	}))
+}
+
+func TestSession_Can(t *testing.T) {
+	s := Session{
+		OwnerID: "1",
+		Characters: []Character{
+			{OwnerID: "2"},
+		},
+		Roles: []RoleAssignment{
+			{UserID: "3", Role: CoGM},
+			{UserID: "4", Role: Spectator},
+		},
+	}
+
+	type test struct {
+		userID string
+		action Action
+		want   bool
+	}
+
+	tests := []test{
+		{"1", ManageRoles, true},
+		{"3", ManageRoles, false},
+		{"3", EditAspects, true},
+		{"3", ManagePlayers, true},
+		{"2", EditAspects, false},
+		{"2", SpendFatePoint, true},
+		{"2", ViewSession, true},
+		{"4", SpendFatePoint, false},
+		{"4", ViewSession, true},
+		{"5", ViewSession, false},
+	}
+
+	for _, test := range tests {
+		expect.WithMessage(t, "%s: %d", test.userID, test.action).That(
+			is.EqualTo(s.Can(test.userID, test.action), test.want),
+		)
+	}
+}
+
+func TestSession_Allows(t *testing.T) {
+	s := Session{}
+	expect.That(t,
+		is.EqualTo(s.Allows(EditAspects), true),
+		is.EqualTo(s.Allows(ManageSession), true),
+	)
+
+	s.Archived = true
+	expect.That(t,
+		is.EqualTo(s.Allows(ViewSession), true),
+		is.EqualTo(s.Allows(ViewHiddenData), true),
+		is.EqualTo(s.Allows(ManageSession), true),
+		is.EqualTo(s.Allows(EditAspects), false),
+		is.EqualTo(s.Allows(EditSession), false),
+		is.EqualTo(s.Allows(SpendFatePoint), false),
+	)
+}
@@ -2,6 +2,7 @@
 package session
 
 import (
+	"slices"
 	"time"
 
 	"github.com/halimath/fate-core-remote-table/backend/internal/id"
@@ -33,8 +34,9 @@ func removeByID[T entity](e *[]T, entityID string) bool {
 }
 
 type Aspect struct {
-	ID   string
-	Name string
+	ID         string
+	Name       string
+	Visibility Visibility
 }
 
 func (a Aspect) id() string {
@@ -61,6 +63,9 @@ type Character struct {
 	Type       CharacterType
 	Name       string
 	FatePoints int
+	// Visibility defines who may see the character. It only applies to NPCs; PCs are always visible to all
+	// members.
+	Visibility Visibility
 	Aspects
 }
 
@@ -68,12 +73,64 @@ func (c Character) id() string {
 	return c.ID
 }
 
+// Invite grants users who are not yet members of a session the right to join it. The invite's ID is the
+// code handed out by the session's owner. An invite may expire at a given time and may be limited to a
+// maximum number of uses. Role defines whether the invite admits players or spectators.
+type Invite struct {
+	ID      string
+	Role    Role
+	Created time.Time
+	// Expires defines the time after which the invite can no longer be used. The zero value means the invite
+	// never expires.
+	Expires time.Time
+	// MaxUses limits the number of times the invite can be used. A value <= 0 means unlimited uses.
+	MaxUses int
+	Uses    int
+}
+
+func (i Invite) id() string {
+	return i.ID
+}
+
+// IsValid returns true if i can be used to join a session at time now.
+func (i Invite) IsValid(now time.Time) bool {
+	if !i.Expires.IsZero() && !now.Before(i.Expires) {
+		return false
+	}
+
+	if i.MaxUses > 0 && i.Uses >= i.MaxUses {
+		return false
+	}
+
+	return true
+}
+
+// Ban bans a user from a session. Banned users can neither join nor load the session.
+type Ban struct {
+	UserID  string
+	Created time.Time
+}
+
+func (b Ban) id() string {
+	return b.UserID
+}
+
 type Session struct {
 	ID           string
 	LastModified time.Time
-	OwnerID      string
-	Title        string
-	Characters   []Character
+	// Version is incremented each time the session is saved. It allows clients to detect concurrent
+	// modifications.
+	Version uint64
+	OwnerID string
+	Title   string
+	// Archived marks a session as read-only. Archived sessions can still be loaded but not be modified.
+	Archived   bool
+	Characters []Character
+	Invites    []Invite
+	Bans       []Ban
+	// Roles holds roles explicitly assigned to users. The owner's role as well as the role of players owning
+	// a character is derived and not contained here.
+	Roles []RoleAssignment
 	Aspects
 }
 
@@ -84,22 +141,43 @@ func New(sessionID, ownerID string, title string) Session {
 		OwnerID:      ownerID,
 		Title:        title,
 		Characters:   make([]Character, 0),
+		Invites:      make([]Invite, 0),
+		Bans:         make([]Ban, 0),
+		Roles:        make([]RoleAssignment, 0),
 		Aspects:      make([]Aspect, 0),
 	}
 }
 
 func (s *Session) IsMember(userID string) bool {
-	if s.OwnerID == userID {
-		return true
+	return s.Role(userID) != NoRole
+}
+
+// VisibleTo returns a copy of s containing only the data userID is permitted to see. Invites and bans are
+// only visible to game masters. Role assignments are kept so that Members reports the same roles to every
+// member. Aspects and NPCs are filtered according to their visibility; the characters selected to see them
+// are only visible to game masters as well.
+func (s Session) VisibleTo(userID string) Session {
+	if s.Can(userID, ViewHiddenData) {
+		return s
 	}
 
+	characters := make([]Character, 0, len(s.Characters))
 	for _, c := range s.Characters {
-		if c.OwnerID == userID {
-			return true
+		if c.Type == NPC && !c.Visibility.visibleTo(&s, userID) {
+			continue
 		}
+
+		c.Visibility = c.Visibility.redacted()
+		c.Aspects = c.Aspects.visibleTo(&s, userID)
+		characters = append(characters, c)
 	}
 
-	return false
+	s.Aspects = s.Aspects.visibleTo(&s, userID)
+	s.Characters = characters
+	s.Invites = nil
+	s.Bans = nil
+
+	return s
 }
 
 func (s *Session) AddCharacter(ownerID string, typ CharacterType, name string, aspects ...Aspect) *Character {
@@ -118,6 +196,84 @@ func (s *Session) RemoveCharacter(characterID string) bool {
 	return removeByID(&s.Characters, characterID)
 }
 
+// CreateInvite creates a new invite for s admitting users with role which expires at expires (the zero value
+// meaning never) and which can be used maxUses times (a value <= 0 meaning unlimited).
+func (s *Session) CreateInvite(role Role, expires time.Time, maxUses int) *Invite {
+	s.Invites = append(s.Invites, Invite{
+		ID:      id.NewForURL(),
+		Role:    role,
+		Created: time.Now().UTC().Truncate(time.Millisecond),
+		Expires: expires,
+		MaxUses: maxUses,
+	})
+
+	return &(s.Invites[len(s.Invites)-1])
+}
+
+// RevokeInvite removes the invite identified by inviteID from s. It returns true if such an invite existed.
+func (s *Session) RevokeInvite(inviteID string) bool {
+	return removeByID(&s.Invites, inviteID)
+}
+
+// UseInvite marks a single use of the invite identified by inviteID to admit a user with role. It returns
+// false, if no such invite exists, if the invite admits a different role or if the invite is no longer valid
+// at time now.
+func (s *Session) UseInvite(inviteID string, role Role, now time.Time) bool {
+	for i := range s.Invites {
+		if s.Invites[i].ID != inviteID || s.Invites[i].Role != role {
+			continue
+		}
+
+		if !s.Invites[i].IsValid(now) {
+			return false
+		}
+
+		s.Invites[i].Uses++
+		return true
+	}
+
+	return false
+}
+
+// RemovePlayer removes all characters owned by userID from s as well as any role explicitly assigned to
+// userID. It returns true if at least one character or role has been removed.
+func (s *Session) RemovePlayer(userID string) bool {
+	l := len(s.Characters)
+	s.Characters = slices.DeleteFunc(s.Characters, func(c Character) bool {
+		return c.OwnerID == userID
+	})
+
+	return removeByID(&s.Roles, userID) || len(s.Characters) < l
+}
+
+// Ban adds userID to the ban list of s. Banning a user that is already banned has no effect.
+func (s *Session) Ban(userID string) {
+	if s.IsBanned(userID) {
+		return
+	}
+
+	s.Bans = append(s.Bans, Ban{
+		UserID:  userID,
+		Created: time.Now().UTC().Truncate(time.Millisecond),
+	})
+}
+
+// LiftBan removes userID from the ban list of s. It returns true if userID has been banned.
+func (s *Session) LiftBan(userID string) bool {
+	return removeByID(&s.Bans, userID)
+}
+
+// IsBanned returns true if userID has been banned from s.
+func (s *Session) IsBanned(userID string) bool {
+	for _, b := range s.Bans {
+		if b.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *Session) FindCharacter(characterID string) *Character {
 	for i := range s.Characters {
 		if s.Characters[i].ID == characterID {
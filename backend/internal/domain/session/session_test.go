@@ -2,6 +2,7 @@ package session
 
 import (
 	"testing"
+	"time"
 
 	"github.com/halimath/expect"
 	"github.com/halimath/expect/is"
@@ -125,3 +126,100 @@ func TestSession_IsMember(t *testing.T) {
 		expect.WithMessage(t, "id: %q", in).That(is.EqualTo(got, want))
 	}
 }
+
+func TestInvite_IsValid(t *testing.T) {
+	now := time.Now()
+
+	tests := map[string]struct {
+		invite Invite
+		want   bool
+	}{
+		"unlimited":   {Invite{}, true},
+		"not_expired": {Invite{Expires: now.Add(time.Minute)}, true},
+		"expired":     {Invite{Expires: now.Add(-time.Minute)}, false},
+		"uses_left":   {Invite{MaxUses: 2, Uses: 1}, true},
+		"used_up":     {Invite{MaxUses: 2, Uses: 2}, false},
+	}
+
+	for name, test := range tests {
+		expect.WithMessage(t, name).That(is.EqualTo(test.invite.IsValid(now), test.want))
+	}
+}
+
+func TestSession_UseInvite(t *testing.T) {
+	s := New(id.NewForURL(), id.New(), "test")
+	invite := s.CreateInvite(Player, time.Time{}, 1)
+
+	expect.That(t,
+		is.EqualTo(s.UseInvite("unknown", Player, time.Now()), false),
+		is.EqualTo(s.UseInvite(invite.ID, Spectator, time.Now()), false),
+		is.EqualTo(s.UseInvite(invite.ID, Player, time.Now()), true),
+		is.EqualTo(s.Invites[0].Uses, 1),
+		is.EqualTo(s.UseInvite(invite.ID, Player, time.Now()), false),
+	)
+}
+
+func TestSession_RevokeInvite(t *testing.T) {
+	s := New(id.NewForURL(), id.New(), "test")
+	invite := s.CreateInvite(Player, time.Time{}, 0)
+
+	ok := s.RevokeInvite(invite.ID)
+
+	expect.That(t,
+		is.EqualTo(ok, true),
+		is.SliceOfLen(s.Invites, 0),
+	)
+}
+
+func TestSession_RemovePlayer(t *testing.T) {
+	s := New(id.NewForURL(), "1", "test")
+	s.AddCharacter("2", PC, "a")
+	c := s.AddCharacter("3", PC, "b")
+	cID := c.ID
+	s.AddCharacter("2", PC, "c")
+
+	expect.That(t,
+		is.EqualTo(s.RemovePlayer("4"), false),
+		is.EqualTo(s.RemovePlayer("2"), true),
+		is.SliceOfLen(s.Characters, 1),
+		is.EqualTo(s.Characters[0].ID, cID),
+	)
+}
+
+func TestSession_Ban(t *testing.T) {
+	s := New(id.NewForURL(), "1", "test")
+
+	s.Ban("2")
+	s.Ban("2")
+
+	expect.That(t,
+		is.SliceOfLen(s.Bans, 1),
+		is.EqualTo(s.IsBanned("2"), true),
+		is.EqualTo(s.IsBanned("3"), false),
+		is.EqualTo(s.LiftBan("2"), true),
+		is.EqualTo(s.IsBanned("2"), false),
+		is.EqualTo(s.LiftBan("2"), false),
+	)
+}
+
+func TestSession_VisibleTo(t *testing.T) {
+	s := Session{
+		OwnerID: "1",
+		Characters: []Character{
+			{OwnerID: "2"},
+		},
+		Invites: []Invite{{ID: "3"}},
+		Bans:    []Ban{{UserID: "4"}},
+		Roles:   []RoleAssignment{{UserID: "5", Role: Spectator}},
+	}
+
+	expect.That(t,
+		is.DeepEqualTo(s.VisibleTo("1"), s),
+		is.DeepEqualTo(s.VisibleTo("2"), Session{
+			OwnerID:    "1",
+			Characters: []Character{{OwnerID: "2"}},
+			Roles:      []RoleAssignment{{UserID: "5", Role: Spectator}},
+		}),
+		is.SliceOfLen(s.Invites, 1),
+	)
+}
@@ -0,0 +1,78 @@
+package session
+
+import "slices"
+
+// VisibilityLevel defines who may see an aspect or a non-player character.
+type VisibilityLevel int
+
+const (
+	// Public data is visible to all members of a session.
+	Public VisibilityLevel = iota
+	// GMOnly data is only visible to game masters.
+	GMOnly
+	// SelectedCharacters data is visible to game masters and to the owners of selected characters.
+	SelectedCharacters
+)
+
+// Visibility defines who may see a piece of session data. The zero value defines public data.
+type Visibility struct {
+	Level VisibilityLevel
+	// CharacterIDs lists the characters whose owners may see the data if Level is SelectedCharacters.
+	CharacterIDs []string
+}
+
+// visibleTo returns true if userID may see data with visibility v in s. It does not consider game masters
+// who may always see all data.
+func (v Visibility) visibleTo(s *Session, userID string) bool {
+	switch v.Level {
+	case Public:
+		return true
+	case SelectedCharacters:
+		for _, c := range s.Characters {
+			if c.OwnerID == userID && slices.Contains(v.CharacterIDs, c.ID) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// redacted returns a copy of v without the selected characters which must not be disclosed to players.
+func (v Visibility) redacted() Visibility {
+	v.CharacterIDs = nil
+	return v
+}
+
+func (a Aspects) visibleTo(s *Session, userID string) Aspects {
+	res := make(Aspects, 0, len(a))
+	for _, aspect := range a {
+		if aspect.Visibility.visibleTo(s, userID) {
+			aspect.Visibility = aspect.Visibility.redacted()
+			res = append(res, aspect)
+		}
+	}
+
+	return res
+}
+
+// FindAspect finds the aspect identified by aspectID either in the session's global aspects or in any
+// character's aspects. It returns nil if no such aspect exists.
+func (s *Session) FindAspect(aspectID string) *Aspect {
+	for i := range s.Aspects {
+		if s.Aspects[i].ID == aspectID {
+			return &s.Aspects[i]
+		}
+	}
+
+	for i := range s.Characters {
+		for j := range s.Characters[i].Aspects {
+			if s.Characters[i].Aspects[j].ID == aspectID {
+				return &s.Characters[i].Aspects[j]
+			}
+		}
+	}
+
+	return nil
+}
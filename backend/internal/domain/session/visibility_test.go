@@ -0,0 +1,95 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestSession_VisibleTo_Visibility(t *testing.T) {
+	s := Session{
+		OwnerID: "1",
+		Characters: []Character{
+			{
+				ID:      "c1",
+				OwnerID: "2",
+				Aspects: Aspects{
+					{ID: "a1"},
+					{ID: "a2", Visibility: Visibility{Level: GMOnly}},
+				},
+			},
+			{ID: "c2", OwnerID: "3"},
+			{ID: "c3", OwnerID: "1", Type: NPC, Visibility: Visibility{Level: GMOnly}},
+			{ID: "c4", OwnerID: "1", Type: NPC, Visibility: Visibility{Level: SelectedCharacters, CharacterIDs: []string{"c2"}}},
+		},
+		Aspects: Aspects{
+			{ID: "a3"},
+			{ID: "a4", Visibility: Visibility{Level: GMOnly}},
+			{ID: "a5", Visibility: Visibility{Level: SelectedCharacters, CharacterIDs: []string{"c1"}}},
+		},
+	}
+
+	characterIDs := func(s Session) []string {
+		var res []string
+		for _, c := range s.Characters {
+			res = append(res, c.ID)
+		}
+		return res
+	}
+
+	aspectIDs := func(a Aspects) []string {
+		var res []string
+		for _, aspect := range a {
+			res = append(res, aspect.ID)
+		}
+		return res
+	}
+
+	gm := s.VisibleTo("1")
+	expect.WithMessage(t, "gm").That(
+		is.DeepEqualTo(characterIDs(gm), []string{"c1", "c2", "c3", "c4"}),
+		is.DeepEqualTo(aspectIDs(gm.Aspects), []string{"a3", "a4", "a5"}),
+		is.DeepEqualTo(aspectIDs(gm.Characters[0].Aspects), []string{"a1", "a2"}),
+	)
+
+	p1 := s.VisibleTo("2")
+	expect.WithMessage(t, "player 1").That(
+		is.DeepEqualTo(characterIDs(p1), []string{"c1", "c2"}),
+		is.DeepEqualTo(aspectIDs(p1.Aspects), []string{"a3", "a5"}),
+		is.DeepEqualTo(aspectIDs(p1.Characters[0].Aspects), []string{"a1"}),
+	)
+
+	p2 := s.VisibleTo("3")
+	expect.WithMessage(t, "player 2").That(
+		is.DeepEqualTo(characterIDs(p2), []string{"c1", "c2", "c4"}),
+		is.DeepEqualTo(aspectIDs(p2.Aspects), []string{"a3"}),
+	)
+
+	expect.WithMessage(t, "selected characters").That(
+		is.DeepEqualTo(gm.Aspects[2].Visibility.CharacterIDs, []string{"c1"}),
+		is.DeepEqualTo(p1.Aspects[1].Visibility, Visibility{Level: SelectedCharacters}),
+		is.DeepEqualTo(p2.Characters[2].Visibility, Visibility{Level: SelectedCharacters}),
+		is.DeepEqualTo(s.Aspects[2].Visibility.CharacterIDs, []string{"c1"}),
+	)
+
+	expect.WithMessage(t, "original").That(
+		is.SliceOfLen(s.Aspects, 3),
+		is.SliceOfLen(s.Characters[0].Aspects, 2),
+	)
+}
+
+func TestSession_FindAspect(t *testing.T) {
+	s := Session{
+		Characters: []Character{
+			{ID: "c1", Aspects: Aspects{{ID: "a1"}}},
+		},
+		Aspects: Aspects{{ID: "a2"}},
+	}
+
+	expect.That(t,
+		is.EqualTo(s.FindAspect("a1"), &s.Characters[0].Aspects[0]),
+		is.EqualTo(s.FindAspect("a2"), &s.Aspects[0]),
+		is.EqualTo(s.FindAspect("a3"), nil),
+	)
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+)
+
+// ErrHistoryUnavailable is a sentinel error value returned when the repository does not record the events
+// of sessions.
+var ErrHistoryUnavailable = errors.New("session history unavailable")
+
+// SessionHistory is implemented by repositories which record all changes to a session as events.
+type SessionHistory interface {
+	// Events returns the records of the session identified by id with a version greater than since.
+	Events(ctx context.Context, id string, since uint64) ([]session.Record, error)
+	// At returns the state of the session identified by id at the given version.
+	At(ctx context.Context, id string, version uint64) (session.Session, error)
+}
+
+// -- ListSessionEvents
+
+type (
+	// ListSessionEventsRequest defines the parameters passed to ListSessionEvents.
+	ListSessionEventsRequest struct {
+		SessionID string
+		// Since restricts the result to events which produced a version greater than Since.
+		Since uint64
+	}
+
+	// ListSessionEvents defines the use case type to list the events recorded for a session.
+	ListSessionEvents UC[ListSessionEventsRequest, []session.Record]
+)
+
+// ProvideListSessionEvents provides a ListSessionEvents use case utilizing r. The use case returns
+// ErrHistoryUnavailable if r does not implement SessionHistory.
+func ProvideListSessionEvents(r SessionRepository) ListSessionEvents {
+	h, supported := r.(SessionHistory)
+
+	return func(ctx context.Context, req ListSessionEventsRequest) ([]session.Record, error) {
+		if err := authorizeHistory(ctx, r, req.SessionID, supported); err != nil {
+			return nil, err
+		}
+
+		return h.Events(ctx, req.SessionID, req.Since)
+	}
+}
+
+// -- LoadSessionAt
+
+type (
+	// LoadSessionAtRequest defines the parameters passed to LoadSessionAt.
+	LoadSessionAtRequest struct {
+		SessionID string
+		Version   uint64
+	}
+
+	// LoadSessionAt defines the use case type to load the state of a session at a past version.
+	LoadSessionAt UC[LoadSessionAtRequest, session.Session]
+)
+
+// ProvideLoadSessionAt provides a LoadSessionAt use case utilizing r. The use case returns
+// ErrHistoryUnavailable if r does not implement SessionHistory.
+func ProvideLoadSessionAt(r SessionRepository) LoadSessionAt {
+	h, supported := r.(SessionHistory)
+
+	return func(ctx context.Context, req LoadSessionAtRequest) (session.Session, error) {
+		if err := authorizeHistory(ctx, r, req.SessionID, supported); err != nil {
+			return session.Session{}, err
+		}
+
+		return h.At(ctx, req.SessionID, req.Version)
+	}
+}
+
+// authorizeHistory verifies that the caller may read the history of the session identified by sessionID.
+// As the history contains hidden data, only game masters may read it. The history is read after the unit of
+// work completed, as repositories lock the session while performing it.
+func authorizeHistory(ctx context.Context, r SessionRepository, sessionID string, supported bool) error {
+	userID, _ := auth.UserID(ctx)
+
+	err := perform(ctx, r, sessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+		if !exists {
+			return s, ErrNotFound
+		}
+
+		if err := authorize(&s, userID, session.ViewHiddenData); err != nil {
+			return s, err
+		}
+
+		return s, NoSave
+	})
+	if err != nil {
+		return err
+	}
+
+	if !supported {
+		return ErrHistoryUnavailable
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+)
+
+// historyRepoMock extends repoMock with a fixed history.
+type historyRepoMock struct {
+	repoMock
+	records []session.Record
+}
+
+func (r *historyRepoMock) Events(ctx context.Context, id string, since uint64) ([]session.Record, error) {
+	var res []session.Record
+	for _, rec := range r.records {
+		if rec.Version > since {
+			res = append(res, rec)
+		}
+	}
+	return res, nil
+}
+
+func (r *historyRepoMock) At(ctx context.Context, id string, version uint64) (session.Session, error) {
+	s := r.s
+	s.Version = version
+	return s, nil
+}
+
+func TestListSessionEvents(t *testing.T) {
+	repo := &historyRepoMock{
+		repoMock: repoMock{
+			s: session.Session{
+				ID:         "1",
+				OwnerID:    "2",
+				Characters: []session.Character{{ID: "3", OwnerID: "4"}},
+			},
+		},
+		records: []session.Record{
+			{Version: 1, Event: session.SessionCreated{}},
+			{Version: 2, Event: session.SessionRenamed{Title: "Renamed"}},
+		},
+	}
+	listEvents := ProvideListSessionEvents(repo)
+	loadAt := ProvideLoadSessionAt(repo)
+
+	gm := auth.WithUserID(context.Background(), "2")
+	player := auth.WithUserID(context.Background(), "4")
+
+	got, err := listEvents(gm, ListSessionEventsRequest{SessionID: "1", Since: 1})
+	expect.That(t,
+		is.NoError(err),
+		is.DeepEqualTo(got, repo.records[1:]),
+	)
+
+	s, err := loadAt(gm, LoadSessionAtRequest{SessionID: "1", Version: 1})
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(s.Version, uint64(1)),
+	)
+
+	_, err = listEvents(player, ListSessionEventsRequest{SessionID: "1"})
+	expect.That(t, is.Error(err, ErrForbidden))
+
+	_, err = loadAt(gm, LoadSessionAtRequest{SessionID: "2", Version: 1})
+	expect.That(t, is.Error(err, ErrNotFound))
+
+	_, err = ProvideListSessionEvents(&repo.repoMock)(gm, ListSessionEventsRequest{SessionID: "1"})
+	expect.That(t, is.Error(err, ErrHistoryUnavailable))
+}
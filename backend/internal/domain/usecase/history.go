@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+)
+
+var (
+	// ErrNothingToUndo is a sentinel error value returned when the session's history contains no change
+	// the caller may undo.
+	ErrNothingToUndo = errors.New("nothing to undo")
+
+	// ErrNothingToRedo is a sentinel error value returned when the session's history contains no undone
+	// change the caller may redo.
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// Undo is returned from a UnitOfWork to revert the last change recorded in the session's history.
+// Repositories record the changes per session and actor (the authenticated user). Recording a change made by
+// a different actor clears the history as the recorded states no longer reflect it.
+var Undo = errors.New("undo")
+
+// Redo is returned from a UnitOfWork to reapply the last change reverted with Undo.
+var Redo = errors.New("redo")
+
+// -- UndoSession
+
+type (
+	// UndoSession defines the use case type to revert the caller's last change to the session identified by
+	// the given id.
+	UndoSession UCNoRet[string]
+)
+
+// ProvideUndoSession provides an UndoSession use case utilizing r.
+func ProvideUndoSession(r SessionRepository) UndoSession {
+	return UndoSession(provideHistoryUseCase(r, Undo))
+}
+
+// -- RedoSession
+
+type (
+	// RedoSession defines the use case type to reapply the caller's last undone change to the session
+	// identified by the given id.
+	RedoSession UCNoRet[string]
+)
+
+// ProvideRedoSession provides a RedoSession use case utilizing r.
+func ProvideRedoSession(r SessionRepository) RedoSession {
+	return RedoSession(provideHistoryUseCase(r, Redo))
+}
+
+func provideHistoryUseCase(r SessionRepository, op error) UCNoRet[string] {
+	return func(ctx context.Context, sessionID string) error {
+		userID, _ := auth.UserID(ctx)
+
+		return perform(ctx, r, sessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.EditSession); err != nil {
+				return s, err
+			}
+
+			return s, op
+		})
+	}
+}
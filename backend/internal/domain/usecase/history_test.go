@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+)
+
+func TestUndoSession(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Title:   "Test",
+			Roles: []session.RoleAssignment{
+				{UserID: "3", Role: session.Player},
+			},
+		},
+	}
+	undoSession := ProvideUndoSession(repo)
+	redoSession := ProvideRedoSession(repo)
+
+	gm := auth.WithUserID(context.Background(), "2")
+	player := auth.WithUserID(context.Background(), "3")
+
+	expect.That(t,
+		is.Error(undoSession(gm, "1"), Undo),
+		is.Error(redoSession(gm, "1"), Redo),
+		is.Error(undoSession(player, "1"), ErrForbidden),
+		is.Error(undoSession(gm, "2"), ErrNotFound),
+		is.Error(undoSession(context.Background(), "1"), ErrForbidden),
+	)
+}
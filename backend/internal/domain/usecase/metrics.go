@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	callsTotal = metrics.Default.NewCounterVec(prometheus.CounterOpts{
+		Name: "fate_table_usecase_calls_total",
+		Help: "Number of use case invocations by outcome",
+	}, []string{"usecase", "outcome"})
+	callDuration = metrics.Default.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fate_table_usecase_duration_seconds",
+		Help:    "Duration of use case invocations",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"usecase"})
+)
+
+// outcomes maps sentinel errors to the outcome label recorded for use case invocations.
+var outcomes = []struct {
+	err     error
+	outcome string
+}{
+	{ErrNotFound, "not_found"},
+	{ErrForbidden, "forbidden"},
+	{ErrValidation, "invalid"},
+	{ErrInvalidCharacter, "invalid"},
+	{ErrInvalidInvite, "invalid"},
+	{ErrInvalidRole, "invalid"},
+	{ErrArchived, "archived"},
+	{ErrQuotaExceeded, "quota_exceeded"},
+	{ErrPreconditionFailed, "precondition_failed"},
+	{ErrNothingToUndo, "nothing_to_undo"},
+	{ErrNothingToRedo, "nothing_to_redo"},
+	{context.DeadlineExceeded, "timeout"},
+	{context.Canceled, "canceled"},
+}
+
+// Outcome classifies err as returned from a use case for use as a metrics label or span attribute.
+func Outcome(err error) string {
+	if err == nil {
+		return "ok"
+	}
+
+	for _, o := range outcomes {
+		if errors.Is(err, o.err) {
+			return o.outcome
+		}
+	}
+
+	return "error"
+}
+
+// Metrics records the number of invocations per outcome and their duration.
+func Metrics(next Handler) Handler {
+	return func(ctx context.Context, inv Invocation) error {
+		start := time.Now()
+		err := next(ctx, inv)
+		callDuration.WithLabelValues(inv.Name).Observe(time.Since(start).Seconds())
+		callsTotal.WithLabelValues(inv.Name, Outcome(err)).Inc()
+		return err
+	}
+}
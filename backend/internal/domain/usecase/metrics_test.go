@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/metrics/metricstest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestOutcome(t *testing.T) {
+	expect.That(t,
+		is.EqualTo(Outcome(nil), "ok"),
+		is.EqualTo(Outcome(ErrForbidden), "forbidden"),
+		is.EqualTo(Outcome(fmt.Errorf("%w: title", ErrQuotaExceeded)), "quota_exceeded"),
+		is.EqualTo(Outcome(&ValidationError{}), "invalid"),
+		is.EqualTo(Outcome(fmt.Errorf("kaboom")), "error"),
+	)
+}
+
+func TestMetrics(t *testing.T) {
+	repo := &repoMock{s: session.Session{ID: "1", OwnerID: "2"}}
+	loadSession := Decorate(Chain{Metrics}, "TestLoadSession", ProvideLoadSession(repo))
+	deleteSession := DecorateNoRet(Chain{Metrics}, "TestDeleteSession", ProvideDeleteSession(repo))
+
+	ctx := auth.WithUserID(context.Background(), "2")
+
+	loadSession(ctx, "1")
+	loadSession(ctx, "2")
+	deleteSession(auth.WithUserID(context.Background(), "3"), "1")
+
+	expect.That(t,
+		is.EqualTo(testutil.ToFloat64(callsTotal.WithLabelValues("TestLoadSession", "ok")), 1.0),
+		is.EqualTo(testutil.ToFloat64(callsTotal.WithLabelValues("TestLoadSession", "not_found")), 1.0),
+		is.EqualTo(testutil.ToFloat64(callsTotal.WithLabelValues("TestDeleteSession", "forbidden")), 1.0),
+		is.EqualTo(metricstest.SampleCount(callDuration.WithLabelValues("TestLoadSession")), uint64(2)),
+	)
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/kvlog"
+)
+
+// ErrPanic is a sentinel error value returned when a use case panicked.
+var ErrPanic = errors.New("use case panicked")
+
+// Invocation describes a single invocation of a use case passed through a Chain.
+type Invocation struct {
+	// Name is the name the use case has been decorated with.
+	Name string
+	// Input is the input passed to the use case.
+	Input any
+}
+
+// Handler handles an Invocation.
+type Handler func(ctx context.Context, inv Invocation) error
+
+// Middleware decorates a Handler to implement a concern shared by all use cases.
+type Middleware func(next Handler) Handler
+
+// Chain is a sequence of Middleware. The first Middleware is the outermost, i.e. it sees an invocation first
+// and its result last.
+type Chain []Middleware
+
+// then returns h decorated with all middleware of c.
+func (c Chain) then(h Handler) Handler {
+	for i := len(c) - 1; i >= 0; i-- {
+		h = c[i](h)
+	}
+	return h
+}
+
+// Decorate decorates uc with c. name identifies uc to the middleware.
+func Decorate[F ~func(context.Context, I) (O, error), I, O any](c Chain, name string, uc F) F {
+	return func(ctx context.Context, in I) (out O, err error) {
+		err = c.then(func(ctx context.Context, inv Invocation) error {
+			var err error
+			out, err = uc(ctx, inv.Input.(I))
+			return err
+		})(ctx, Invocation{Name: name, Input: in})
+		return
+	}
+}
+
+// DecorateNoRet decorates uc with c. name identifies uc to the middleware.
+func DecorateNoRet[F ~func(context.Context, I) error, I any](c Chain, name string, uc F) F {
+	return func(ctx context.Context, in I) error {
+		return c.then(func(ctx context.Context, inv Invocation) error {
+			return uc(ctx, inv.Input.(I))
+		})(ctx, Invocation{Name: name, Input: in})
+	}
+}
+
+// Log logs invocations failing with an unexpected error, i.e. an error not classified by Outcome.
+func Log(next Handler) Handler {
+	return func(ctx context.Context, inv Invocation) error {
+		start := time.Now()
+		err := next(ctx, inv)
+		if Outcome(err) == "error" {
+			userID, _ := auth.UserID(ctx)
+			kvlog.FromContext(ctx).Logs("use case failed", kvlog.WithKV("usecase", inv.Name),
+				kvlog.WithKV("user", userID), kvlog.WithKV("duration", time.Since(start)), kvlog.WithErr(err))
+		}
+		return err
+	}
+}
+
+// Recover recovers from panics raised by a use case and returns them as an error matching ErrPanic.
+func Recover(next Handler) Handler {
+	return func(ctx context.Context, inv Invocation) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				kvlog.FromContext(ctx).Logs("use case panicked", kvlog.WithKV("usecase", inv.Name),
+					kvlog.WithKV("panic", r), kvlog.WithKV("stack", string(debug.Stack())))
+				err = fmt.Errorf("%w: %v", ErrPanic, r)
+			}
+		}()
+
+		return next(ctx, inv)
+	}
+}
+
+// Timeout limits the time an invocation may take to d. The deadline is passed on with the context; a use
+// case which has already been started runs to completion, but repositories abort units of work whose
+// deadline expired while waiting for the session. A d <= 0 disables the timeout.
+func Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		if d <= 0 {
+			return next
+		}
+
+		return func(ctx context.Context, inv Invocation) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			return next(ctx, inv)
+		}
+	}
+}
+
+// Authenticated rejects invocations from unauthenticated users with ErrForbidden. Use cases rely on it and
+// do not check the caller's authentication themselves.
+func Authenticated(next Handler) Handler {
+	return func(ctx context.Context, inv Invocation) error {
+		if !auth.IsAuthorized(ctx) {
+			return ErrForbidden
+		}
+
+		return next(ctx, inv)
+	}
+}
+
+// Validatable is implemented by use case inputs which validate themselves independently of the session
+// they refer to.
+type Validatable interface {
+	Validate() error
+}
+
+// Validate rejects invocations with invalid input. Inputs referring to a session must contain a session id;
+// inputs implementing Validatable must pass their validation.
+func Validate(next Handler) Handler {
+	return func(ctx context.Context, inv Invocation) error {
+		if sessionID, ok := sessionIDOf(inv.Input); ok && len(sessionID) == 0 {
+			var v validator
+			v.fail("id", "must not be empty")
+			return v.err()
+		}
+
+		if v, ok := inv.Input.(Validatable); ok {
+			if err := v.Validate(); err != nil {
+				return err
+			}
+		}
+
+		return next(ctx, inv)
+	}
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+)
+
+func TestChain_order(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, inv Invocation) error {
+				calls = append(calls, name+">"+inv.Name)
+				err := next(ctx, inv)
+				calls = append(calls, name+"<")
+				return err
+			}
+		}
+	}
+
+	uc := Decorate(Chain{record("a"), record("b")}, "Echo", func(ctx context.Context, in string) (string, error) {
+		calls = append(calls, "uc")
+		return in, nil
+	})
+
+	out, err := uc(context.Background(), "hello")
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(out, "hello"),
+		is.DeepEqualTo(calls, []string{"a>Echo", "b>Echo", "uc", "b<", "a<"}),
+	)
+}
+
+func TestRecover(t *testing.T) {
+	uc := DecorateNoRet(Chain{Recover}, "Panic", func(ctx context.Context, in string) error {
+		panic("kaboom")
+	})
+
+	err := uc(context.Background(), "1")
+	expect.That(t,
+		is.Error(err, ErrPanic),
+		is.EqualTo(Outcome(err), "error"),
+	)
+}
+
+func TestTimeout(t *testing.T) {
+	uc := DecorateNoRet(Chain{Timeout(time.Millisecond)}, "Slow", func(ctx context.Context, in string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := uc(context.Background(), "1")
+	expect.That(t,
+		is.Error(err, context.DeadlineExceeded),
+		is.EqualTo(Outcome(err), "timeout"),
+	)
+
+	uc = DecorateNoRet(Chain{Timeout(0)}, "Unlimited", func(ctx context.Context, in string) error {
+		_, ok := ctx.Deadline()
+		expect.That(t, is.EqualTo(ok, false))
+		return nil
+	})
+	expect.That(t, is.NoError(uc(context.Background(), "1")))
+}
+
+func TestAuthenticated(t *testing.T) {
+	repo := &repoMock{s: session.Session{ID: "1", OwnerID: "2"}}
+	loadSession := Decorate(Chain{Authenticated}, "LoadSession", ProvideLoadSession(repo))
+
+	_, err := loadSession(context.Background(), "1")
+	expect.That(t, is.Error(err, ErrForbidden))
+
+	_, err = loadSession(auth.WithUserID(context.Background(), "2"), "1")
+	expect.That(t, is.NoError(err))
+}
+
+type validatableRequest struct {
+	SessionID string
+	Count     int
+}
+
+func (r validatableRequest) Validate() error {
+	if r.Count < 0 {
+		return &ValidationError{Fields: []FieldError{{Field: "count", Message: "must not be negative"}}}
+	}
+	return nil
+}
+
+func TestValidate(t *testing.T) {
+	uc := DecorateNoRet(Chain{Validate}, "Validated", func(ctx context.Context, in validatableRequest) error {
+		return nil
+	})
+
+	var validationErr *ValidationError
+
+	err := uc(context.Background(), validatableRequest{})
+	expect.That(t,
+		is.Error(err, ErrValidation),
+		is.EqualTo(errors.As(err, &validationErr), true),
+		is.DeepEqualTo(validationErr.Fields, []FieldError{{Field: "id", Message: "must not be empty"}}),
+	)
+
+	err = uc(context.Background(), validatableRequest{SessionID: "1", Count: -1})
+	expect.That(t, is.Error(err, ErrValidation))
+
+	err = uc(context.Background(), validatableRequest{SessionID: "1", Count: 1})
+	expect.That(t, is.NoError(err))
+
+	listSessions := Decorate(Chain{Validate}, "ListSessions", func(ctx context.Context, in struct{}) ([]session.Session, error) {
+		return nil, nil
+	})
+	_, err = listSessions(context.Background(), struct{}{})
+	expect.That(t, is.NoError(err))
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+)
+
+// Quotas defines resource limits enforced by the use cases. A limit <= 0 disables the respective check.
+type Quotas struct {
+	// MaxSessionsPerUser limits the number of sessions a single user may own.
+	MaxSessionsPerUser int
+	// MaxCharactersPerSession limits the number of characters (both PCs and NPCs) in a session.
+	MaxCharactersPerSession int
+	// MaxAspectsPerSession limits the number of aspects in a session including all character aspects.
+	MaxAspectsPerSession int
+	// MaxNameLength limits the number of characters of session titles as well as character and aspect names.
+	// Exceeding it results in a ValidationError.
+	MaxNameLength int
+}
+
+// checkName trims leading and trailing whitespace from the name value points to and verifies that the
+// result does not exceed MaxNameLength. field names the validated field in the returned ValidationError.
+func (q Quotas) checkName(field string, value *string) error {
+	*value = strings.TrimSpace(*value)
+
+	if q.MaxNameLength > 0 && utf8.RuneCountInString(*value) > q.MaxNameLength {
+		var v validator
+		v.fail(field, "must not be longer than %d characters", q.MaxNameLength)
+		return v.err()
+	}
+	return nil
+}
+
+func (q Quotas) checkSessions(owned int) error {
+	if q.MaxSessionsPerUser > 0 && owned >= q.MaxSessionsPerUser {
+		return fmt.Errorf("%w: a user must not own more than %d sessions", ErrQuotaExceeded, q.MaxSessionsPerUser)
+	}
+	return nil
+}
+
+func (q Quotas) checkCharacters(s *session.Session) error {
+	if q.MaxCharactersPerSession > 0 && len(s.Characters) >= q.MaxCharactersPerSession {
+		return fmt.Errorf("%w: a session must not contain more than %d characters", ErrQuotaExceeded, q.MaxCharactersPerSession)
+	}
+	return nil
+}
+
+func (q Quotas) checkAspects(s *session.Session) error {
+	if q.MaxAspectsPerSession <= 0 {
+		return nil
+	}
+
+	n := len(s.Aspects)
+	for _, c := range s.Characters {
+		n += len(c.Aspects)
+	}
+
+	if n >= q.MaxAspectsPerSession {
+		return fmt.Errorf("%w: a session must not contain more than %d aspects", ErrQuotaExceeded, q.MaxAspectsPerSession)
+	}
+	return nil
+}
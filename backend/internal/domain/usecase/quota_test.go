@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+)
+
+func TestQuotas(t *testing.T) {
+	q := Quotas{
+		MaxSessionsPerUser:      1,
+		MaxCharactersPerSession: 1,
+		MaxAspectsPerSession:    2,
+		MaxNameLength:           4,
+	}
+
+	t.Run("sessions_per_user", func(t *testing.T) {
+		repo := &repoMock{}
+		createSession := ProvideCreateSession(repo, q)
+		ctx := auth.WithUserID(context.Background(), "2")
+
+		_, err := createSession(ctx, CreateSessionRequest{Title: "Test"})
+		expect.That(t, is.NoError(err))
+
+		_, err = createSession(ctx, CreateSessionRequest{Title: "Test"})
+		expect.That(t, is.Error(err, ErrQuotaExceeded))
+	})
+
+	t.Run("characters_per_session", func(t *testing.T) {
+		repo := &repoMock{
+			s: session.Session{
+				ID:      "1",
+				OwnerID: "2",
+				Characters: []session.Character{
+					{ID: "3", OwnerID: "4"},
+				},
+				Invites: []session.Invite{
+					{ID: "5", Role: session.Player},
+				},
+			},
+		}
+		ctx := auth.WithUserID(context.Background(), "6")
+
+		_, err := ProvideJoinSession(repo, q)(ctx, JoinSessionRequest{SessionID: "1", CharacterName: "Test", InviteID: "5"})
+		expect.That(t,
+			is.Error(err, ErrQuotaExceeded),
+			is.SliceOfLen(repo.s.Characters, 1),
+		)
+	})
+
+	t.Run("aspects_per_session", func(t *testing.T) {
+		repo := &repoMock{
+			s: session.Session{
+				ID:      "1",
+				OwnerID: "2",
+				Aspects: []session.Aspect{
+					{ID: "3", Name: "Test"},
+				},
+				Characters: []session.Character{
+					{ID: "4", OwnerID: "5", Aspects: []session.Aspect{{ID: "6", Name: "Test"}}},
+				},
+			},
+		}
+		ctx := auth.WithUserID(context.Background(), "2")
+
+		_, err := ProvideCreateAspect(repo, q)(ctx, CreateAspectRequest{SessionID: "1", Name: "Test"})
+		expect.That(t,
+			is.Error(err, ErrQuotaExceeded),
+			is.SliceOfLen(repo.s.Aspects, 1),
+		)
+
+		_, err = ProvideCreateCharacterAspect(repo, q)(ctx, CreateCharacterAspectRequest{
+			CreateAspectRequest: CreateAspectRequest{SessionID: "1", Name: "Test"},
+			CharacterID:         "4",
+		})
+		expect.That(t,
+			is.Error(err, ErrQuotaExceeded),
+			is.SliceOfLen(repo.s.Characters[0].Aspects, 1),
+		)
+	})
+	t.Run("name_length", func(t *testing.T) {
+		repo := &repoMock{s: session.Session{ID: "1", OwnerID: "2"}}
+		updateSession := ProvideUpdateSession(repo, q)
+		ctx := auth.WithUserID(context.Background(), "2")
+
+		err := updateSession(ctx, UpdateSessionRequest{SessionID: "1", Title: "Tests"})
+		expect.That(t,
+			is.Error(err, ErrValidation),
+			is.EqualTo(err.Error(), "validation failed: title must not be longer than 4 characters"),
+		)
+
+		err = updateSession(ctx, UpdateSessionRequest{SessionID: "1", Title: " Test\t"})
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Title, "Test"),
+		)
+	})
+}
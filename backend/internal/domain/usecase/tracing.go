@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// outcomeKey is the span attribute holding the outcome of a use case invocation as returned by Outcome.
+const outcomeKey = attribute.Key("usecase.outcome")
+
+// Tracing creates a span for each invocation named after the use case.
+func Tracing(next Handler) Handler {
+	return func(ctx context.Context, inv Invocation) error {
+		ctx, span := startSpan(ctx, inv.Name, inv.Input)
+		err := next(ctx, inv)
+		endSpan(span, err)
+		return err
+	}
+}
+
+func startSpan(ctx context.Context, name string, in any) (context.Context, trace.Span) {
+	var attrs []attribute.KeyValue
+	if userID, ok := auth.UserID(ctx); ok {
+		attrs = append(attrs, tracing.UserID.String(userID))
+	}
+	if sessionID, _ := sessionIDOf(in); len(sessionID) > 0 {
+		attrs = append(attrs, tracing.SessionID.String(sessionID))
+	}
+
+	return tracing.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func endSpan(span trace.Span, err error) {
+	outcome := Outcome(err)
+	span.SetAttributes(outcomeKey.String(outcome))
+	// Expected outcomes such as ErrNotFound are recorded as attribute only.
+	if outcome == "error" {
+		tracing.Fail(span, err)
+	}
+	span.End()
+}
+
+// sessionIDOf returns the id of the session a use case is invoked for. Use cases either receive the
+// session's id as their input or a request struct with a SessionID field. ok is false if in refers to no
+// session.
+func sessionIDOf(in any) (id string, ok bool) {
+	if id, ok := in.(string); ok {
+		return id, true
+	}
+
+	v := reflect.ValueOf(in)
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	if f := v.FieldByName("SessionID"); f.IsValid() && f.Kind() == reflect.String {
+		return f.String(), true
+	}
+
+	return "", false
+}
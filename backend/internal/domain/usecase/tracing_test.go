@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	repo := &repoMock{s: session.Session{ID: "1", OwnerID: "2"}}
+	loadSession := Decorate(Chain{Tracing}, "LoadSession", ProvideLoadSession(repo))
+	archiveSession := DecorateNoRet(Chain{Tracing}, "ArchiveSession", ProvideArchiveSession(repo))
+
+	loadSession(auth.WithUserID(context.Background(), "2"), "1")
+	archiveSession(auth.WithUserID(context.Background(), "3"), ArchiveSessionRequest{SessionID: "1", Archived: true})
+
+	spans := exporter.GetSpans()
+	expect.That(t,
+		is.EqualTo(len(spans), 2),
+		is.EqualTo(spans[0].Name, "LoadSession"),
+		is.DeepEqualTo(spans[0].Attributes, []attribute.KeyValue{
+			attribute.String("user.id", "2"),
+			attribute.String("session.id", "1"),
+			attribute.String("usecase.outcome", "ok"),
+		}),
+		is.EqualTo(spans[1].Name, "ArchiveSession"),
+		is.DeepEqualTo(spans[1].Attributes, []attribute.KeyValue{
+			attribute.String("user.id", "3"),
+			attribute.String("session.id", "1"),
+			attribute.String("usecase.outcome", "forbidden"),
+		}),
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
 	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
@@ -20,6 +21,20 @@ var (
 	// ErrInvalidCharacter is a sentinel error value returned when an operation targets a character and that
 	// character does not exist or is otherwise invalid.
 	ErrInvalidCharacter = errors.New("invlid character")
+
+	// ErrInvalidInvite is a sentinel error value returned when a user tries to join a session with an invite
+	// that does not exist, has expired or has been used up.
+	ErrInvalidInvite = errors.New("invalid invite")
+
+	// ErrInvalidRole is a sentinel error value returned when a role cannot be assigned to a user.
+	ErrInvalidRole = errors.New("invalid role")
+
+	// ErrArchived is a sentinel error value returned when trying to modify an archived session.
+	ErrArchived = errors.New("session archived")
+
+	// ErrQuotaExceeded is a sentinel error value returned when an operation would exceed one of the
+	// configured Quotas.
+	ErrQuotaExceeded = errors.New("quota exceeded")
 )
 
 // UC is a generic function type that is used to define use case functions that
@@ -41,12 +56,32 @@ type (
 	CreateSession UC[CreateSessionRequest, session.Session]
 )
 
-// ProvideCreateSession provides a CreateSession use case utilizing r.
-func ProvideCreateSession(r SessionRepository) CreateSession {
+func (r CreateSessionRequest) Validate() error {
+	var v validator
+	v.name("title", r.Title)
+	return v.err()
+}
+
+// ProvideCreateSession provides a CreateSession use case utilizing r and enforcing q.
+func ProvideCreateSession(r SessionRepository, q Quotas) CreateSession {
 	return func(ctx context.Context, req CreateSessionRequest) (ses session.Session, err error) {
-		userID, ok := auth.UserID(ctx)
-		if !ok {
-			return session.Session{}, ErrForbidden
+		userID, _ := auth.UserID(ctx)
+
+		if err := q.checkName("title", &req.Title); err != nil {
+			return session.Session{}, err
+		}
+
+		if q.MaxSessionsPerUser > 0 {
+			// The number of sessions is checked outside of the unit of work, so concurrent requests may
+			// exceed the limit slightly.
+			owned, err := r.CountByOwner(ctx, userID)
+			if err != nil {
+				return session.Session{}, err
+			}
+
+			if err := q.checkSessions(owned); err != nil {
+				return session.Session{}, err
+			}
 		}
 
 		ses = session.Session{
@@ -74,21 +109,18 @@ type LoadSession UC[string, session.Session]
 // ProvideLoadSession creates a Func to load a session given its ID.
 func ProvideLoadSession(r SessionRepository) LoadSession {
 	return func(ctx context.Context, sessionID string) (ses session.Session, err error) {
-		userID, ok := auth.UserID(ctx)
-		if !ok {
-			return session.Session{}, ErrForbidden
-		}
+		userID, _ := auth.UserID(ctx)
 
-		err = r.Perform(ctx, sessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+		err = perform(ctx, r, sessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
 			if !exists {
 				return s, ErrNotFound
 			}
 
-			if !s.IsMember(userID) {
-				return s, ErrForbidden
+			if err := authorize(&s, userID, session.ViewSession); err != nil {
+				return s, err
 			}
 
-			ses = s
+			ses = s.VisibleTo(userID)
 			return s, NoSave
 		})
 		return
@@ -101,24 +133,56 @@ type (
 	JoinSessionRequest struct {
 		SessionID     string
 		CharacterName string
+		// InviteID is the code of the invite used to join the session. It is only required for users who are
+		// not already players of the session.
+		InviteID string
 	}
 
 	JoinSession UC[JoinSessionRequest, string]
 )
 
-func ProvideJoinSession(r SessionRepository) JoinSession {
+func (r JoinSessionRequest) Validate() error {
+	var v validator
+	v.name("name", r.CharacterName)
+	return v.err()
+}
+
+func ProvideJoinSession(r SessionRepository, q Quotas) JoinSession {
 	return func(ctx context.Context, req JoinSessionRequest) (characterID string, err error) {
-		userID, ok := auth.UserID(ctx)
-		if !ok {
-			return "", ErrForbidden
+		userID, _ := auth.UserID(ctx)
+
+		if err := q.checkName("name", &req.CharacterName); err != nil {
+			return "", err
 		}
 
-		err = r.Perform(ctx, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+		err = perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
 			if !exists {
 				return s, ErrNotFound
 			}
 
+			if s.IsBanned(userID) {
+				return s, ErrForbidden
+			}
+
+			if s.Archived {
+				return s, ErrArchived
+			}
+
+			promote := s.Role(userID) < session.Player
+			if promote && !s.UseInvite(req.InviteID, session.Player, time.Now()) {
+				return s, ErrInvalidInvite
+			}
+
+			if err := q.checkCharacters(&s); err != nil {
+				return s, err
+			}
+
 			characterID = s.AddCharacter(userID, session.PC, req.CharacterName).ID
+
+			if promote {
+				// Drop a spectator role so the user's role is derived from the character.
+				s.SetRole(userID, session.Player)
+			}
 			return s, nil
 		})
 
@@ -126,34 +190,324 @@ func ProvideJoinSession(r SessionRepository) JoinSession {
 	}
 }
 
+// -- SpectateSession
+
+type (
+	// SpectateSessionRequest defines the parameters passed to SpectateSession.
+	SpectateSessionRequest struct {
+		SessionID, InviteID string
+	}
+
+	// SpectateSession defines the use case type to become a spectator of a session using a spectator invite.
+	// Spectators may load the session but not modify it.
+	SpectateSession UCNoRet[SpectateSessionRequest]
+)
+
+// ProvideSpectateSession provides a SpectateSession use case utilizing r.
+func ProvideSpectateSession(r SessionRepository) SpectateSession {
+	return func(ctx context.Context, req SpectateSessionRequest) error {
+		userID, _ := auth.UserID(ctx)
+
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if s.IsBanned(userID) {
+				return s, ErrForbidden
+			}
+
+			if s.Archived {
+				return s, ErrArchived
+			}
+
+			if s.IsMember(userID) {
+				return s, NoSave
+			}
+
+			if !s.UseInvite(req.InviteID, session.Spectator, time.Now()) {
+				return s, ErrInvalidInvite
+			}
+
+			s.SetRole(userID, session.Spectator)
+
+			return s, nil
+		})
+	}
+}
+
+// -- CreateInvite
+
+type (
+	// CreateInviteRequest defines the parameters passed to CreateInvite.
+	CreateInviteRequest struct {
+		SessionID string
+		// Role defines the role of users joining with the invite. It must be either session.Player or
+		// session.Spectator. The zero value defaults to session.Player.
+		Role session.Role
+		// TTL defines the duration the invite is valid for. A value <= 0 creates an invite that never
+		// expires.
+		TTL time.Duration
+		// MaxUses limits the number of joins using the invite. A value <= 0 means unlimited uses.
+		MaxUses int
+	}
+
+	// CreateInvite defines the use case type to create an invite to join a session.
+	CreateInvite UC[CreateInviteRequest, session.Invite]
+)
+
+// ProvideCreateInvite provides a CreateInvite use case utilizing r.
+func ProvideCreateInvite(r SessionRepository) CreateInvite {
+	return func(ctx context.Context, req CreateInviteRequest) (invite session.Invite, err error) {
+		userID, _ := auth.UserID(ctx)
+
+		err = perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.ManageInvites); err != nil {
+				return s, err
+			}
+
+			role := req.Role
+			if role == session.NoRole {
+				role = session.Player
+			}
+
+			if role != session.Player && role != session.Spectator {
+				return s, fmt.Errorf("%w: %s", ErrInvalidRole, role)
+			}
+
+			var expires time.Time
+			if req.TTL > 0 {
+				expires = time.Now().Add(req.TTL).UTC().Truncate(time.Millisecond)
+			}
+
+			invite = *s.CreateInvite(role, expires, req.MaxUses)
+			return s, nil
+		})
+
+		return
+	}
+}
+
+// -- ListInvites
+
+// ListInvites defines the use case type to list all invites of a session.
+type ListInvites UC[string, []session.Invite]
+
+// ProvideListInvites provides a ListInvites use case utilizing r.
+func ProvideListInvites(r SessionRepository) ListInvites {
+	return func(ctx context.Context, sessionID string) (invites []session.Invite, err error) {
+		userID, _ := auth.UserID(ctx)
+
+		err = perform(ctx, r, sessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.ManageInvites); err != nil {
+				return s, err
+			}
+
+			invites = append(make([]session.Invite, 0, len(s.Invites)), s.Invites...)
+			return s, NoSave
+		})
+
+		return
+	}
+}
+
+// -- RevokeInvite
+
+type (
+	// RevokeInviteRequest defines the parameters passed to RevokeInvite.
+	RevokeInviteRequest struct {
+		SessionID, InviteID string
+	}
+
+	// RevokeInvite defines the use case type to revoke an invite so it can no longer be used to join.
+	RevokeInvite UCNoRet[RevokeInviteRequest]
+)
+
+// ProvideRevokeInvite provides a RevokeInvite use case utilizing r.
+func ProvideRevokeInvite(r SessionRepository) RevokeInvite {
+	return func(ctx context.Context, req RevokeInviteRequest) error {
+		userID, _ := auth.UserID(ctx)
+
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.ManageInvites); err != nil {
+				return s, err
+			}
+
+			if !s.RevokeInvite(req.InviteID) {
+				return s, ErrNotFound
+			}
+
+			return s, nil
+		})
+	}
+}
+
+// -- KickPlayer
+
+type (
+	// KickPlayerRequest defines the parameters passed to KickPlayer.
+	KickPlayerRequest struct {
+		SessionID, UserID string
+		// Ban defines whether the user should also be banned from the session.
+		Ban bool
+	}
+
+	// KickPlayer defines the use case type to remove a player and all of the player's characters from a
+	// session and optionally ban the player from rejoining.
+	KickPlayer UCNoRet[KickPlayerRequest]
+)
+
+// ProvideKickPlayer provides a KickPlayer use case utilizing r.
+func ProvideKickPlayer(r SessionRepository) KickPlayer {
+	return func(ctx context.Context, req KickPlayerRequest) error {
+		userID, _ := auth.UserID(ctx)
+
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.ManagePlayers); err != nil {
+				return s, err
+			}
+
+			if s.Role(req.UserID) >= s.Role(userID) {
+				return s, ErrForbidden
+			}
+
+			if !s.RemovePlayer(req.UserID) && !req.Ban {
+				return s, ErrNotFound
+			}
+
+			if req.Ban {
+				s.Ban(req.UserID)
+			}
+
+			return s, nil
+		})
+	}
+}
+
+// -- ListBans
+
+// ListBans defines the use case type to list all bans of a session.
+type ListBans UC[string, []session.Ban]
+
+// ProvideListBans provides a ListBans use case utilizing r.
+func ProvideListBans(r SessionRepository) ListBans {
+	return func(ctx context.Context, sessionID string) (bans []session.Ban, err error) {
+		userID, _ := auth.UserID(ctx)
+
+		err = perform(ctx, r, sessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.ManagePlayers); err != nil {
+				return s, err
+			}
+
+			bans = append(make([]session.Ban, 0, len(s.Bans)), s.Bans...)
+			return s, NoSave
+		})
+
+		return
+	}
+}
+
+// -- LiftBan
+
+type (
+	// LiftBanRequest defines the parameters passed to LiftBan.
+	LiftBanRequest struct {
+		SessionID, UserID string
+	}
+
+	// LiftBan defines the use case type to lift a user's ban from a session.
+	LiftBan UCNoRet[LiftBanRequest]
+)
+
+// ProvideLiftBan provides a LiftBan use case utilizing r.
+func ProvideLiftBan(r SessionRepository) LiftBan {
+	return func(ctx context.Context, req LiftBanRequest) error {
+		userID, _ := auth.UserID(ctx)
+
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.ManagePlayers); err != nil {
+				return s, err
+			}
+
+			if !s.LiftBan(req.UserID) {
+				return s, ErrNotFound
+			}
+
+			return s, nil
+		})
+	}
+}
+
 // -- CreateAspect
 
 type (
 	CreateAspectRequest struct {
-		SessionID string
-		Name      string
+		SessionID  string
+		Name       string
+		Visibility session.Visibility
 	}
 
 	CreateAspect UC[CreateAspectRequest, string]
 )
 
-func ProvideCreateAspect(r SessionRepository) CreateAspect {
+func (r CreateAspectRequest) Validate() error {
+	var v validator
+	v.name("name", r.Name)
+	return v.err()
+}
+
+func ProvideCreateAspect(r SessionRepository, q Quotas) CreateAspect {
 	return func(ctx context.Context, req CreateAspectRequest) (aspectID string, err error) {
-		userID, ok := auth.UserID(ctx)
-		if !ok {
-			return "", ErrForbidden
+		userID, _ := auth.UserID(ctx)
+
+		if err := q.checkName("name", &req.Name); err != nil {
+			return "", err
 		}
 
-		err = r.Perform(ctx, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+		err = perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
 			if !exists {
 				return s, ErrNotFound
 			}
 
-			if s.OwnerID != userID {
-				return s, ErrForbidden
+			if err := authorize(&s, userID, session.EditAspects); err != nil {
+				return s, err
+			}
+
+			if err := validateVisibility(&s, req.Visibility); err != nil {
+				return s, err
+			}
+
+			if err := q.checkAspects(&s); err != nil {
+				return s, err
 			}
 
-			aspectID = s.AddAspect(req.Name).ID
+			aspect := s.AddAspect(req.Name)
+			aspect.Visibility = req.Visibility
+			aspectID = aspect.ID
 			return s, nil
 		})
 
@@ -173,18 +527,15 @@ type (
 
 func ProvideDeleteAspect(r SessionRepository) DeleteAspect {
 	return func(ctx context.Context, req DeleteAspectRequest) error {
-		userID, ok := auth.UserID(ctx)
-		if !ok {
-			return ErrForbidden
-		}
+		userID, _ := auth.UserID(ctx)
 
-		return r.Perform(ctx, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
 			if !exists {
 				return s, ErrNotFound
 			}
 
-			if s.OwnerID != userID {
-				return s, ErrForbidden
+			if err := authorize(&s, userID, session.EditAspects); err != nil {
+				return s, err
 			}
 
 			if ok := s.RemoveAspect(req.AspectID); ok {
@@ -213,20 +564,21 @@ type (
 	CreateCharacterAspect UC[CreateCharacterAspectRequest, string]
 )
 
-func ProvideCreateCharacterAspect(r SessionRepository) CreateCharacterAspect {
+func ProvideCreateCharacterAspect(r SessionRepository, q Quotas) CreateCharacterAspect {
 	return func(ctx context.Context, req CreateCharacterAspectRequest) (aspectID string, err error) {
-		userID, ok := auth.UserID(ctx)
-		if !ok {
-			return "", ErrForbidden
+		userID, _ := auth.UserID(ctx)
+
+		if err := q.checkName("name", &req.Name); err != nil {
+			return "", err
 		}
 
-		err = r.Perform(ctx, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+		err = perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
 			if !exists {
 				return s, ErrNotFound
 			}
 
-			if s.OwnerID != userID {
-				return s, ErrForbidden
+			if err := authorize(&s, userID, session.EditCharacters); err != nil {
+				return s, err
 			}
 
 			c := s.FindCharacter(req.CharacterID)
@@ -234,7 +586,17 @@ func ProvideCreateCharacterAspect(r SessionRepository) CreateCharacterAspect {
 				return s, fmt.Errorf("%w: character not found: %s", ErrInvalidCharacter, req.CharacterID)
 			}
 
-			aspectID = c.AddAspect(req.Name).ID
+			if err := validateVisibility(&s, req.Visibility); err != nil {
+				return s, err
+			}
+
+			if err := q.checkAspects(&s); err != nil {
+				return s, err
+			}
+
+			aspect := c.AddAspect(req.Name)
+			aspect.Visibility = req.Visibility
+			aspectID = aspect.ID
 			return s, nil
 		})
 
@@ -242,6 +604,97 @@ func ProvideCreateCharacterAspect(r SessionRepository) CreateCharacterAspect {
 	}
 }
 
+// -- SetAspectVisibility
+
+type (
+	// SetAspectVisibilityRequest defines the parameters passed to SetAspectVisibility.
+	SetAspectVisibilityRequest struct {
+		SessionID, AspectID string
+		Visibility          session.Visibility
+	}
+
+	// SetAspectVisibility defines the use case type to change the visibility of an aspect, i.e. to reveal a
+	// hidden aspect to the players.
+	SetAspectVisibility UCNoRet[SetAspectVisibilityRequest]
+)
+
+// ProvideSetAspectVisibility provides a SetAspectVisibility use case utilizing r.
+func ProvideSetAspectVisibility(r SessionRepository) SetAspectVisibility {
+	return func(ctx context.Context, req SetAspectVisibilityRequest) error {
+		userID, _ := auth.UserID(ctx)
+
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.EditAspects); err != nil {
+				return s, err
+			}
+
+			if err := validateVisibility(&s, req.Visibility); err != nil {
+				return s, err
+			}
+
+			aspect := s.FindAspect(req.AspectID)
+			if aspect == nil {
+				return s, ErrNotFound
+			}
+
+			aspect.Visibility = req.Visibility
+
+			return s, nil
+		})
+	}
+}
+
+// -- SetCharacterVisibility
+
+type (
+	// SetCharacterVisibilityRequest defines the parameters passed to SetCharacterVisibility.
+	SetCharacterVisibilityRequest struct {
+		SessionID, CharacterID string
+		Visibility             session.Visibility
+	}
+
+	// SetCharacterVisibility defines the use case type to change the visibility of a non-player character.
+	SetCharacterVisibility UCNoRet[SetCharacterVisibilityRequest]
+)
+
+// ProvideSetCharacterVisibility provides a SetCharacterVisibility use case utilizing r.
+func ProvideSetCharacterVisibility(r SessionRepository) SetCharacterVisibility {
+	return func(ctx context.Context, req SetCharacterVisibilityRequest) error {
+		userID, _ := auth.UserID(ctx)
+
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.EditCharacters); err != nil {
+				return s, err
+			}
+
+			c := s.FindCharacter(req.CharacterID)
+			if c == nil {
+				return s, fmt.Errorf("%w: character not found: %s", ErrInvalidCharacter, req.CharacterID)
+			}
+
+			if c.Type != session.NPC {
+				return s, fmt.Errorf("%w: not an NPC: %s", ErrInvalidCharacter, req.CharacterID)
+			}
+
+			if err := validateVisibility(&s, req.Visibility); err != nil {
+				return s, err
+			}
+
+			c.Visibility = req.Visibility
+
+			return s, nil
+		})
+	}
+}
+
 // -- UpdateFatePoints
 
 type (
@@ -255,12 +708,9 @@ type (
 
 func ProvideUpdateFatePoints(r SessionRepository) UpdateFatePoints {
 	return func(ctx context.Context, req UpdateFatePointsRequest) error {
-		userID, ok := auth.UserID(ctx)
-		if !ok {
-			return ErrForbidden
-		}
+		userID, _ := auth.UserID(ctx)
 
-		return r.Perform(ctx, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
 			if !exists {
 				return s, ErrNotFound
 			}
@@ -270,10 +720,18 @@ func ProvideUpdateFatePoints(r SessionRepository) UpdateFatePoints {
 				return s, fmt.Errorf("%w: character does not exist: %s", ErrInvalidCharacter, req.CharacterID)
 			}
 
-			if s.OwnerID != userID {
+			// Game masters may change fate points in any way; a character's owner may only spend a single fate
+			// point.
+			action := session.EditCharacters
+			if !s.Can(userID, action) {
 				if req.Delta != -1 || c.OwnerID != userID {
 					return s, ErrForbidden
 				}
+				action = session.SpendFatePoint
+			}
+
+			if err := authorize(&s, userID, action); err != nil {
+				return s, err
 			}
 
 			c.FatePoints += req.Delta
@@ -283,12 +741,219 @@ func ProvideUpdateFatePoints(r SessionRepository) UpdateFatePoints {
 	}
 }
 
+// -- SetMemberRole
+
+type (
+	// SetMemberRoleRequest defines the parameters passed to SetMemberRole.
+	SetMemberRoleRequest struct {
+		SessionID, UserID string
+		Role              session.Role
+	}
+
+	// SetMemberRole defines the use case type to change the role of a session's member, i.e. to promote a
+	// player to co-GM or to demote a co-GM back to player.
+	SetMemberRole UCNoRet[SetMemberRoleRequest]
+)
+
+// ProvideSetMemberRole provides a SetMemberRole use case utilizing r.
+func ProvideSetMemberRole(r SessionRepository) SetMemberRole {
+	return func(ctx context.Context, req SetMemberRoleRequest) error {
+		userID, _ := auth.UserID(ctx)
+
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.ManageRoles); err != nil {
+				return s, err
+			}
+
+			if req.Role != session.CoGM && req.Role != session.Player {
+				return s, fmt.Errorf("%w: %s", ErrInvalidRole, req.Role)
+			}
+
+			if req.UserID == s.OwnerID || !s.IsMember(req.UserID) {
+				return s, fmt.Errorf("%w: not a member: %s", ErrInvalidRole, req.UserID)
+			}
+
+			s.SetRole(req.UserID, req.Role)
+
+			return s, nil
+		})
+	}
+}
+
+// -- ListSessions
+
+type (
+	// ListSessions defines the use case type to list all sessions owned by the current user. Sessions are
+	// ordered by last modification with the most recently modified session first.
+	ListSessions UC[struct{}, []session.Session]
+)
+
+// ProvideListSessions provides a ListSessions use case utilizing r.
+func ProvideListSessions(r SessionRepository) ListSessions {
+	return func(ctx context.Context, _ struct{}) ([]session.Session, error) {
+		userID, _ := auth.UserID(ctx)
+
+		return r.List(ctx, func(s session.Session) bool {
+			return s.OwnerID == userID
+		})
+	}
+}
+
+// -- UpdateSession
+
+type (
+	// UpdateSessionRequest defines the parameters passed to UpdateSession.
+	UpdateSessionRequest struct {
+		SessionID, Title string
+	}
+
+	// UpdateSession defines the use case type to update a session's title.
+	UpdateSession UCNoRet[UpdateSessionRequest]
+)
+
+func (r UpdateSessionRequest) Validate() error {
+	var v validator
+	v.name("title", r.Title)
+	return v.err()
+}
+
+// ProvideUpdateSession provides an UpdateSession use case utilizing r and enforcing q.
+func ProvideUpdateSession(r SessionRepository, q Quotas) UpdateSession {
+	return func(ctx context.Context, req UpdateSessionRequest) error {
+		userID, _ := auth.UserID(ctx)
+
+		if err := q.checkName("title", &req.Title); err != nil {
+			return err
+		}
+
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.EditSession); err != nil {
+				return s, err
+			}
+
+			s.Title = req.Title
+
+			return s, nil
+		})
+	}
+}
+
+// -- ArchiveSession
+
+type (
+	// ArchiveSessionRequest defines the parameters passed to ArchiveSession.
+	ArchiveSessionRequest struct {
+		SessionID string
+		// Archived defines whether the session gets archived (true) or restored (false).
+		Archived bool
+	}
+
+	// ArchiveSession defines the use case type to archive or restore a session. Archived sessions are
+	// read-only.
+	ArchiveSession UCNoRet[ArchiveSessionRequest]
+)
+
+// ProvideArchiveSession provides an ArchiveSession use case utilizing r.
+func ProvideArchiveSession(r SessionRepository) ArchiveSession {
+	return func(ctx context.Context, req ArchiveSessionRequest) error {
+		userID, _ := auth.UserID(ctx)
+
+		return perform(ctx, r, req.SessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.ManageSession); err != nil {
+				return s, err
+			}
+
+			if s.Archived == req.Archived {
+				return s, NoSave
+			}
+
+			s.Archived = req.Archived
+
+			return s, nil
+		})
+	}
+}
+
+// -- DeleteSession
+
+type (
+	// DeleteSession defines the use case type to delete the session identified by the given id.
+	DeleteSession UCNoRet[string]
+)
+
+// ProvideDeleteSession provides a DeleteSession use case utilizing r.
+func ProvideDeleteSession(r SessionRepository) DeleteSession {
+	return func(ctx context.Context, sessionID string) error {
+		userID, _ := auth.UserID(ctx)
+
+		return perform(ctx, r, sessionID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				return s, ErrNotFound
+			}
+
+			if err := authorize(&s, userID, session.ManageSession); err != nil {
+				return s, err
+			}
+
+			return s, RemoveSession
+		})
+	}
+}
+
 // --
 
+// authorize implements the central authorization policy applied by all use cases. It returns ErrForbidden
+// unless userID is permitted to perform a on s and ErrArchived if s is archived and a modifies s.
+func authorize(s *session.Session, userID string, a session.Action) error {
+	if !s.Can(userID, a) {
+		return ErrForbidden
+	}
+
+	if !s.Allows(a) {
+		return ErrArchived
+	}
+
+	return nil
+}
+
+// validateVisibility validates that all characters referenced by v exist in s.
+func validateVisibility(s *session.Session, v session.Visibility) error {
+	if v.Level != session.SelectedCharacters {
+		return nil
+	}
+
+	for _, characterID := range v.CharacterIDs {
+		if s.FindCharacter(characterID) == nil {
+			return fmt.Errorf("%w: character not found: %s", ErrInvalidCharacter, characterID)
+		}
+	}
+
+	return nil
+}
+
 var NoSave = errors.New("no save")
 
+// RemoveSession is returned from a UnitOfWork to remove the session from the repository.
+var RemoveSession = errors.New("remove session")
+
 type UnitOfWork func(context.Context, bool, session.Session) (session.Session, error)
 
 type SessionRepository interface {
 	Perform(ctx context.Context, id string, uow UnitOfWork) error
+	// List returns all sessions for which filter returns true ordered by LastModified descending.
+	List(ctx context.Context, filter func(s session.Session) bool) ([]session.Session, error)
+	// CountByOwner returns the number of sessions owned by ownerID.
+	CountByOwner(ctx context.Context, ownerID string) (int, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/halimath/expect"
 	"github.com/halimath/expect/is"
@@ -21,6 +22,11 @@ func (r *repoMock) Perform(ctx context.Context, id string, uow UnitOfWork) error
 		return nil
 	}
 
+	if errors.Is(err, RemoveSession) {
+		r.s = session.Session{}
+		return nil
+	}
+
 	if err != nil {
 		return err
 	}
@@ -30,6 +36,22 @@ func (r *repoMock) Perform(ctx context.Context, id string, uow UnitOfWork) error
 	return nil
 }
 
+func (r *repoMock) List(ctx context.Context, filter func(s session.Session) bool) ([]session.Session, error) {
+	if r.s.ID == "" || !filter(r.s) {
+		return []session.Session{}, nil
+	}
+
+	return []session.Session{r.s}, nil
+}
+
+func (r *repoMock) CountByOwner(ctx context.Context, ownerID string) (int, error) {
+	if r.s.ID == "" || r.s.OwnerID != ownerID {
+		return 0, nil
+	}
+
+	return 1, nil
+}
+
 type repoFixture struct {
 	repo SessionRepository
 }
@@ -39,143 +61,859 @@ func (f *repoFixture) BeforeEach(t *testing.TB) error {
 	return nil
 }
 
-func TestLoadSession(t *testing.T) {
+func TestLoadSession(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+		},
+	}
+	loadSession := ProvideLoadSession(repo)
+
+	t.Run("found", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+
+		got, err := loadSession(ctx, "1")
+		expect.That(t,
+			is.NoError(err),
+			is.DeepEqualTo(got, session.Session{ID: "1", OwnerID: "2"}),
+		)
+	})
+
+	t.Run("player", func(t *testing.T) {
+		repo := &repoMock{
+			s: session.Session{
+				ID:         "1",
+				OwnerID:    "2",
+				Characters: []session.Character{{ID: "c1", OwnerID: "3"}},
+				Roles: []session.RoleAssignment{
+					{UserID: "4", Role: session.CoGM},
+					{UserID: "5", Role: session.Spectator},
+				},
+				Invites: []session.Invite{{ID: "6", Role: session.Player}},
+			},
+		}
+
+		got, err := ProvideLoadSession(repo)(auth.WithUserID(context.Background(), "3"), "1")
+		expect.That(t,
+			is.NoError(err),
+			is.SliceOfLen(got.Invites, 0),
+			is.DeepEqualTo(got.Members(), []session.RoleAssignment{
+				{UserID: "2", Role: session.Owner},
+				{UserID: "4", Role: session.CoGM},
+				{UserID: "5", Role: session.Spectator},
+				{UserID: "3", Role: session.Player},
+			}),
+		)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		got, err := loadSession(ctx, "2")
+
+		expect.That(t,
+			is.Error(err, ErrNotFound),
+			is.DeepEqualTo(got, session.Session{}),
+		)
+	})
+
+	t.Run("no_user", func(t *testing.T) {
+		ctx := context.Background()
+		got, err := loadSession(ctx, "1")
+
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.DeepEqualTo(got, session.Session{}),
+		)
+	})
+
+	t.Run("not_authorized", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "3")
+		got, err := loadSession(ctx, "1")
+
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.DeepEqualTo(got, session.Session{}),
+		)
+	})
+}
+
+func TestCreateSession(t *testing.T) {
+	repo := &repoMock{}
+	createSession := Decorate(Chain{Authenticated}, "CreateSession", ProvideCreateSession(repo, Quotas{}))
+
+	t.Run("no_user", func(t *testing.T) {
+		ctx := context.Background()
+		got, err := createSession(ctx, CreateSessionRequest{})
+
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.DeepEqualTo(got, session.Session{}),
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+
+		got, err := createSession(ctx, CreateSessionRequest{
+			Title: "Test",
+		})
+
+		expect.That(t,
+			is.NoError(err),
+			is.DeepEqualTo(got, session.Session{OwnerID: "2", Title: "Test"}, is.ExcludeFields{"ID"}),
+		)
+	})
+}
+
+func TestJoinSession(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Invites: []session.Invite{
+				{ID: "5", Role: session.Player, MaxUses: 1},
+			},
+		},
+	}
+	joinSession := Decorate(Chain{Authenticated}, "JoinSession", ProvideJoinSession(repo, Quotas{}))
+
+	t.Run("not_authorized", func(t *testing.T) {
+		characterID, err := joinSession(context.Background(), JoinSessionRequest{
+			SessionID:     "1",
+			CharacterName: "Test",
+			InviteID:      "5",
+		})
+
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.EqualTo(characterID, ""),
+			is.SliceOfLen(repo.s.Characters, 0),
+		)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "3")
+
+		characterID, err := joinSession(ctx, JoinSessionRequest{
+			SessionID:     "99",
+			CharacterName: "Test",
+			InviteID:      "5",
+		})
+
+		expect.That(t,
+			is.Error(err, ErrNotFound),
+			is.EqualTo(characterID, ""),
+			is.SliceOfLen(repo.s.Characters, 0),
+		)
+	})
+
+	t.Run("no_invite", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "3")
+
+		characterID, err := joinSession(ctx, JoinSessionRequest{
+			SessionID:     "1",
+			CharacterName: "Test",
+		})
+
+		expect.That(t,
+			is.Error(err, ErrInvalidInvite),
+			is.EqualTo(characterID, ""),
+			is.SliceOfLen(repo.s.Characters, 0),
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "3")
+
+		characterID, err := joinSession(ctx, JoinSessionRequest{
+			SessionID:     "1",
+			CharacterName: "Test",
+			InviteID:      "5",
+		})
+
+		expect.That(t,
+			is.NoError(err),
+			is.DeepEqualTo(repo.s, session.Session{
+				ID:      "1",
+				OwnerID: "2",
+				Characters: []session.Character{
+					{
+						ID:      characterID,
+						OwnerID: "3",
+						Name:    "Test",
+						Type:    session.PC,
+					},
+				},
+				Invites: []session.Invite{
+					{ID: "5", Role: session.Player, MaxUses: 1, Uses: 1},
+				},
+			}),
+		)
+	})
+
+	t.Run("invite_used_up", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "4")
+
+		characterID, err := joinSession(ctx, JoinSessionRequest{
+			SessionID:     "1",
+			CharacterName: "Test",
+			InviteID:      "5",
+		})
+
+		expect.That(t,
+			is.Error(err, ErrInvalidInvite),
+			is.EqualTo(characterID, ""),
+			is.SliceOfLen(repo.s.Characters, 1),
+		)
+	})
+
+	t.Run("member_without_invite", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "3")
+
+		characterID, err := joinSession(ctx, JoinSessionRequest{
+			SessionID:     "1",
+			CharacterName: "Test 2",
+		})
+
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Characters[1].ID, characterID),
+		)
+	})
+}
+
+func TestSpectateSession(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Invites: []session.Invite{
+				{ID: "3", Role: session.Spectator},
+				{ID: "4", Role: session.Player},
+			},
+		},
+	}
+	spectateSession := ProvideSpectateSession(repo)
+	ctx := auth.WithUserID(context.Background(), "5")
+
+	t.Run("player_invite", func(t *testing.T) {
+		err := spectateSession(ctx, SpectateSessionRequest{SessionID: "1", InviteID: "4"})
+		expect.That(t,
+			is.Error(err, ErrInvalidInvite),
+			is.EqualTo(repo.s.Role("5"), session.NoRole),
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		err := spectateSession(ctx, SpectateSessionRequest{SessionID: "1", InviteID: "3"})
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Role("5"), session.Spectator),
+		)
+	})
+
+	t.Run("load_redacted", func(t *testing.T) {
+		got, err := ProvideLoadSession(repo)(ctx, "1")
+		expect.That(t,
+			is.NoError(err),
+			is.SliceOfLen(got.Invites, 0),
+			is.EqualTo(got.Role("5"), session.Spectator),
+		)
+	})
+
+	t.Run("mutation_forbidden", func(t *testing.T) {
+		_, err := ProvideCreateAspect(repo, Quotas{})(ctx, CreateAspectRequest{SessionID: "1", Name: "Test"})
+		expect.That(t, is.Error(err, ErrForbidden))
+
+		_, err = ProvideCreateInvite(repo)(ctx, CreateInviteRequest{SessionID: "1"})
+		expect.That(t, is.Error(err, ErrForbidden))
+	})
+
+	t.Run("join_without_invite", func(t *testing.T) {
+		_, err := ProvideJoinSession(repo, Quotas{})(ctx, JoinSessionRequest{SessionID: "1", CharacterName: "Test"})
+		expect.That(t,
+			is.Error(err, ErrInvalidInvite),
+			is.SliceOfLen(repo.s.Characters, 0),
+		)
+	})
+
+	t.Run("join_with_invite", func(t *testing.T) {
+		_, err := ProvideJoinSession(repo, Quotas{})(ctx, JoinSessionRequest{SessionID: "1", CharacterName: "Test", InviteID: "4"})
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Role("5"), session.Player),
+		)
+	})
+}
+
+func TestCreateInvite(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+		},
+	}
+	createInvite := ProvideCreateInvite(repo)
+
+	t.Run("not_authorized", func(t *testing.T) {
+		_, err := createInvite(context.Background(), CreateInviteRequest{SessionID: "1"})
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.SliceOfLen(repo.s.Invites, 0),
+		)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		_, err := createInvite(ctx, CreateInviteRequest{SessionID: "99"})
+		expect.That(t,
+			is.Error(err, ErrNotFound),
+			is.SliceOfLen(repo.s.Invites, 0),
+		)
+	})
+
+	t.Run("not_gm", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "3")
+		_, err := createInvite(ctx, CreateInviteRequest{SessionID: "1"})
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.SliceOfLen(repo.s.Invites, 0),
+		)
+	})
+
+	t.Run("invalid_role", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		_, err := createInvite(ctx, CreateInviteRequest{SessionID: "1", Role: session.CoGM})
+		expect.That(t,
+			is.Error(err, ErrInvalidRole),
+			is.SliceOfLen(repo.s.Invites, 0),
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		got, err := createInvite(ctx, CreateInviteRequest{
+			SessionID: "1",
+			TTL:       time.Hour,
+			MaxUses:   3,
+		})
+		expect.That(t,
+			is.NoError(err),
+			is.DeepEqualTo(repo.s.Invites, []session.Invite{got}),
+			is.EqualTo(got.MaxUses, 3),
+			is.EqualTo(got.Role, session.Player),
+			is.EqualTo(got.Expires.After(time.Now()), true),
+		)
+	})
+}
+
+func TestListInvites(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Invites: []session.Invite{
+				{ID: "3"},
+			},
+		},
+	}
+	listInvites := ProvideListInvites(repo)
+
+	t.Run("not_gm", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "3")
+		_, err := listInvites(ctx, "1")
+		expect.That(t, is.Error(err, ErrForbidden))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		got, err := listInvites(ctx, "1")
+		expect.That(t,
+			is.NoError(err),
+			is.DeepEqualTo(got, []session.Invite{{ID: "3"}}),
+		)
+	})
+}
+
+func TestRevokeInvite(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Invites: []session.Invite{
+				{ID: "3"},
+			},
+		},
+	}
+	revokeInvite := ProvideRevokeInvite(repo)
+
+	t.Run("not_gm", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "3")
+		err := revokeInvite(ctx, RevokeInviteRequest{SessionID: "1", InviteID: "3"})
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.SliceOfLen(repo.s.Invites, 1),
+		)
+	})
+
+	t.Run("invite_not_found", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := revokeInvite(ctx, RevokeInviteRequest{SessionID: "1", InviteID: "4"})
+		expect.That(t, is.Error(err, ErrNotFound))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := revokeInvite(ctx, RevokeInviteRequest{SessionID: "1", InviteID: "3"})
+		expect.That(t,
+			is.NoError(err),
+			is.SliceOfLen(repo.s.Invites, 0),
+		)
+	})
+}
+
+func TestKickPlayer(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Characters: []session.Character{
+				{ID: "3", OwnerID: "4"},
+				{ID: "5", OwnerID: "6"},
+				{ID: "7", OwnerID: "4"},
+			},
+		},
+	}
+	kickPlayer := ProvideKickPlayer(repo)
+
+	t.Run("not_authorized", func(t *testing.T) {
+		err := kickPlayer(context.Background(), KickPlayerRequest{SessionID: "1", UserID: "4"})
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.SliceOfLen(repo.s.Characters, 3),
+		)
+	})
+
+	t.Run("not_gm", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "6")
+		err := kickPlayer(ctx, KickPlayerRequest{SessionID: "1", UserID: "4"})
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.SliceOfLen(repo.s.Characters, 3),
+		)
+	})
+
+	t.Run("kick_owner", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := kickPlayer(ctx, KickPlayerRequest{SessionID: "1", UserID: "2", Ban: true})
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.SliceOfLen(repo.s.Bans, 0),
+		)
+	})
+
+	t.Run("player_not_found", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := kickPlayer(ctx, KickPlayerRequest{SessionID: "1", UserID: "8"})
+		expect.That(t, is.Error(err, ErrNotFound))
+	})
+
+	t.Run("kick", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := kickPlayer(ctx, KickPlayerRequest{SessionID: "1", UserID: "4"})
+		expect.That(t,
+			is.NoError(err),
+			is.DeepEqualTo(repo.s.Characters, []session.Character{{ID: "5", OwnerID: "6"}}),
+			is.SliceOfLen(repo.s.Bans, 0),
+		)
+	})
+
+	t.Run("ban", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := kickPlayer(ctx, KickPlayerRequest{SessionID: "1", UserID: "6", Ban: true})
+		expect.That(t,
+			is.NoError(err),
+			is.SliceOfLen(repo.s.Characters, 0),
+			is.EqualTo(repo.s.IsBanned("6"), true),
+		)
+	})
+}
+
+func TestBannedUser(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Invites: []session.Invite{
+				{ID: "3", Role: session.Player},
+			},
+			Bans: []session.Ban{
+				{UserID: "4"},
+			},
+		},
+	}
+
+	ctx := auth.WithUserID(context.Background(), "4")
+
+	_, err := ProvideLoadSession(repo)(ctx, "1")
+	expect.WithMessage(t, "load").That(is.Error(err, ErrForbidden))
+
+	_, err = ProvideJoinSession(repo, Quotas{})(ctx, JoinSessionRequest{
+		SessionID:     "1",
+		CharacterName: "Test",
+		InviteID:      "3",
+	})
+	expect.WithMessage(t, "join").That(
+		is.Error(err, ErrForbidden),
+		is.SliceOfLen(repo.s.Characters, 0),
+	)
+}
+
+func TestListBans(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Bans: []session.Ban{
+				{UserID: "3"},
+			},
+		},
+	}
+	listBans := ProvideListBans(repo)
+
+	t.Run("not_gm", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "3")
+		_, err := listBans(ctx, "1")
+		expect.That(t, is.Error(err, ErrForbidden))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		got, err := listBans(ctx, "1")
+		expect.That(t,
+			is.NoError(err),
+			is.DeepEqualTo(got, []session.Ban{{UserID: "3"}}),
+		)
+	})
+}
+
+func TestLiftBan(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Bans: []session.Ban{
+				{UserID: "3"},
+			},
+		},
+	}
+	liftBan := ProvideLiftBan(repo)
+
+	t.Run("not_gm", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "3")
+		err := liftBan(ctx, LiftBanRequest{SessionID: "1", UserID: "3"})
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.SliceOfLen(repo.s.Bans, 1),
+		)
+	})
+
+	t.Run("ban_not_found", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := liftBan(ctx, LiftBanRequest{SessionID: "1", UserID: "4"})
+		expect.That(t, is.Error(err, ErrNotFound))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := liftBan(ctx, LiftBanRequest{SessionID: "1", UserID: "3"})
+		expect.That(t,
+			is.NoError(err),
+			is.SliceOfLen(repo.s.Bans, 0),
+		)
+	})
+}
+
+func TestSetMemberRole(t *testing.T) {
 	repo := &repoMock{
 		s: session.Session{
 			ID:      "1",
 			OwnerID: "2",
+			Characters: []session.Character{
+				{ID: "3", OwnerID: "4"},
+				{ID: "5", OwnerID: "6"},
+			},
 		},
 	}
-	loadSession := ProvideLoadSession(repo)
+	setMemberRole := ProvideSetMemberRole(repo)
 
-	t.Run("found", func(t *testing.T) {
+	t.Run("not_owner", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "4")
+		err := setMemberRole(ctx, SetMemberRoleRequest{SessionID: "1", UserID: "4", Role: session.CoGM})
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.SliceOfLen(repo.s.Roles, 0),
+		)
+	})
+
+	t.Run("invalid_role", func(t *testing.T) {
 		ctx := auth.WithUserID(context.Background(), "2")
+		err := setMemberRole(ctx, SetMemberRoleRequest{SessionID: "1", UserID: "4", Role: session.Owner})
+		expect.That(t,
+			is.Error(err, ErrInvalidRole),
+			is.SliceOfLen(repo.s.Roles, 0),
+		)
+	})
 
-		got, err := loadSession(ctx, "1")
+	t.Run("not_a_member", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := setMemberRole(ctx, SetMemberRoleRequest{SessionID: "1", UserID: "7", Role: session.CoGM})
 		expect.That(t,
-			is.NoError(err),
-			is.DeepEqualTo(got, session.Session{ID: "1", OwnerID: "2"}),
+			is.Error(err, ErrInvalidRole),
+			is.SliceOfLen(repo.s.Roles, 0),
 		)
 	})
 
-	t.Run("not_found", func(t *testing.T) {
+	t.Run("promote", func(t *testing.T) {
 		ctx := auth.WithUserID(context.Background(), "2")
-		got, err := loadSession(ctx, "2")
+		err := setMemberRole(ctx, SetMemberRoleRequest{SessionID: "1", UserID: "4", Role: session.CoGM})
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Role("4"), session.CoGM),
+		)
+	})
 
+	t.Run("co_gm_edits_aspects", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "4")
+		_, err := ProvideCreateAspect(repo, Quotas{})(ctx, CreateAspectRequest{SessionID: "1", Name: "Test"})
 		expect.That(t,
-			is.Error(err, ErrNotFound),
-			is.DeepEqualTo(got, session.Session{}),
+			is.NoError(err),
+			is.SliceOfLen(repo.s.Aspects, 1),
 		)
 	})
 
-	t.Run("no_user", func(t *testing.T) {
-		ctx := context.Background()
-		got, err := loadSession(ctx, "1")
+	t.Run("co_gm_updates_fate_points", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "4")
+		err := ProvideUpdateFatePoints(repo)(ctx, UpdateFatePointsRequest{SessionID: "1", CharacterID: "5", Delta: 2})
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Characters[1].FatePoints, 2),
+		)
+	})
 
+	t.Run("co_gm_cannot_kick_owner", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "4")
+		err := ProvideKickPlayer(repo)(ctx, KickPlayerRequest{SessionID: "1", UserID: "2", Ban: true})
 		expect.That(t,
 			is.Error(err, ErrForbidden),
-			is.DeepEqualTo(got, session.Session{}),
+			is.SliceOfLen(repo.s.Bans, 0),
+		)
+	})
+
+	t.Run("demote", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := setMemberRole(ctx, SetMemberRoleRequest{SessionID: "1", UserID: "4", Role: session.Player})
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Role("4"), session.Player),
+		)
+	})
+
+	t.Run("demote_without_character", func(t *testing.T) {
+		repo.s.Roles = append(repo.s.Roles, session.RoleAssignment{UserID: "8", Role: session.CoGM})
+
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := setMemberRole(ctx, SetMemberRoleRequest{SessionID: "1", UserID: "8", Role: session.Player})
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Role("8"), session.Player),
+			is.EqualTo(repo.s.IsMember("8"), true),
 		)
 	})
+}
+
+func TestListSessions(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+		},
+	}
+	listSessions := Decorate(Chain{Authenticated}, "ListSessions", ProvideListSessions(repo))
 
 	t.Run("not_authorized", func(t *testing.T) {
+		_, err := listSessions(context.Background(), struct{}{})
+		expect.That(t, is.Error(err, ErrForbidden))
+	})
+
+	t.Run("not_owner", func(t *testing.T) {
 		ctx := auth.WithUserID(context.Background(), "3")
-		got, err := loadSession(ctx, "1")
+		got, err := listSessions(ctx, struct{}{})
+		expect.That(t,
+			is.NoError(err),
+			is.SliceOfLen(got, 0),
+		)
+	})
 
+	t.Run("success", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		got, err := listSessions(ctx, struct{}{})
 		expect.That(t,
-			is.Error(err, ErrForbidden),
-			is.DeepEqualTo(got, session.Session{}),
+			is.NoError(err),
+			is.DeepEqualTo(got, []session.Session{repo.s}),
 		)
 	})
 }
 
-func TestCreateSession(t *testing.T) {
-	repo := &repoMock{}
-	createSession := ProvideCreateSession(repo)
+func TestUpdateSession(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Title:   "Test",
+			Characters: []session.Character{
+				{ID: "3", OwnerID: "4"},
+			},
+		},
+	}
+	updateSession := ProvideUpdateSession(repo, Quotas{})
 
-	t.Run("no_user", func(t *testing.T) {
-		ctx := context.Background()
-		got, err := createSession(ctx, CreateSessionRequest{})
+	t.Run("not_found", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := updateSession(ctx, UpdateSessionRequest{SessionID: "2", Title: "Renamed"})
+		expect.That(t, is.Error(err, ErrNotFound))
+	})
 
+	t.Run("not_gm", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "4")
+		err := updateSession(ctx, UpdateSessionRequest{SessionID: "1", Title: "Renamed"})
 		expect.That(t,
 			is.Error(err, ErrForbidden),
-			is.DeepEqualTo(got, session.Session{}),
+			is.EqualTo(repo.s.Title, "Test"),
 		)
 	})
 
 	t.Run("success", func(t *testing.T) {
 		ctx := auth.WithUserID(context.Background(), "2")
-
-		got, err := createSession(ctx, CreateSessionRequest{
-			Title: "Test",
-		})
-
+		err := updateSession(ctx, UpdateSessionRequest{SessionID: "1", Title: "Renamed"})
 		expect.That(t,
 			is.NoError(err),
-			is.DeepEqualTo(got, session.Session{OwnerID: "2", Title: "Test"}, is.ExcludeFields{"ID"}),
+			is.EqualTo(repo.s.Title, "Renamed"),
 		)
 	})
 }
 
-func TestJoinSession(t *testing.T) {
+func TestArchiveSession(t *testing.T) {
 	repo := &repoMock{
 		s: session.Session{
 			ID:      "1",
 			OwnerID: "2",
+			Title:   "Test",
+			Characters: []session.Character{
+				{ID: "3", OwnerID: "4"},
+			},
+			Invites: []session.Invite{
+				{ID: "5", Role: session.Player},
+			},
 		},
 	}
-	joinSession := ProvideJoinSession(repo)
-
-	t.Run("not_authorized", func(t *testing.T) {
-		characterID, err := joinSession(context.Background(), JoinSessionRequest{
-			SessionID:     "1",
-			CharacterName: "Test",
-		})
+	archiveSession := ProvideArchiveSession(repo)
 
+	t.Run("not_owner", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "4")
+		err := archiveSession(ctx, ArchiveSessionRequest{SessionID: "1", Archived: true})
 		expect.That(t,
 			is.Error(err, ErrForbidden),
-			is.EqualTo(characterID, ""),
-			is.SliceOfLen(repo.s.Characters, 0),
+			is.EqualTo(repo.s.Archived, false),
 		)
 	})
 
-	t.Run("not_found", func(t *testing.T) {
-		ctx := auth.WithUserID(context.Background(), "3")
+	t.Run("archive", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := archiveSession(ctx, ArchiveSessionRequest{SessionID: "1", Archived: true})
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Archived, true),
+		)
+	})
 
-		characterID, err := joinSession(ctx, JoinSessionRequest{
-			SessionID:     "99",
-			CharacterName: "Test",
-		})
+	t.Run("load_archived", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "4")
+		_, err := ProvideLoadSession(repo)(ctx, "1")
+		expect.That(t, is.NoError(err))
+	})
 
+	t.Run("update_archived", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := ProvideUpdateSession(repo, Quotas{})(ctx, UpdateSessionRequest{SessionID: "1", Title: "Renamed"})
 		expect.That(t,
-			is.Error(err, ErrNotFound),
-			is.EqualTo(characterID, ""),
-			is.SliceOfLen(repo.s.Characters, 0),
+			is.Error(err, ErrArchived),
+			is.EqualTo(repo.s.Title, "Test"),
 		)
 	})
 
-	t.Run("success", func(t *testing.T) {
-		ctx := auth.WithUserID(context.Background(), "3")
+	t.Run("update_fate_points_archived", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := ProvideUpdateFatePoints(repo)(ctx, UpdateFatePointsRequest{SessionID: "1", CharacterID: "3", Delta: 1})
+		expect.That(t,
+			is.Error(err, ErrArchived),
+			is.EqualTo(repo.s.Characters[0].FatePoints, 0),
+		)
+	})
 
-		characterID, err := joinSession(ctx, JoinSessionRequest{
-			SessionID:     "1",
-			CharacterName: "Test",
-		})
+	t.Run("join_archived", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "6")
+		_, err := ProvideJoinSession(repo, Quotas{})(ctx, JoinSessionRequest{SessionID: "1", CharacterName: "Test", InviteID: "5"})
+		expect.That(t,
+			is.Error(err, ErrArchived),
+			is.SliceOfLen(repo.s.Characters, 1),
+		)
+	})
 
+	t.Run("restore", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := archiveSession(ctx, ArchiveSessionRequest{SessionID: "1", Archived: false})
 		expect.That(t,
 			is.NoError(err),
-			is.DeepEqualTo(repo.s, session.Session{
-				ID:      "1",
-				OwnerID: "2",
-				Characters: []session.Character{
-					{
-						ID:      characterID,
-						OwnerID: "3",
-						Name:    "Test",
-						Type:    session.PC,
-					},
-				},
-			}),
+			is.EqualTo(repo.s.Archived, false),
+		)
+	})
+}
+
+func TestDeleteSession(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Characters: []session.Character{
+				{ID: "3", OwnerID: "4"},
+			},
+		},
+	}
+	deleteSession := ProvideDeleteSession(repo)
+
+	t.Run("not_found", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := deleteSession(ctx, "2")
+		expect.That(t, is.Error(err, ErrNotFound))
+	})
+
+	t.Run("not_owner", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "4")
+		err := deleteSession(ctx, "1")
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.EqualTo(repo.s.ID, "1"),
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := deleteSession(ctx, "1")
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.ID, ""),
 		)
 	})
 }
@@ -187,7 +925,7 @@ func TestCreateAspect(t *testing.T) {
 			OwnerID: "2",
 		},
 	}
-	createAspect := ProvideCreateAspect(repo)
+	createAspect := ProvideCreateAspect(repo, Quotas{})
 
 	t.Run("not_found", func(t *testing.T) {
 		ctx := auth.WithUserID(context.Background(), "2")
@@ -206,7 +944,7 @@ func TestCreateAspect(t *testing.T) {
 
 	t.Run("not_authorized", func(t *testing.T) {
 		aspectID, err := createAspect(context.Background(), CreateAspectRequest{
-			SessionID: "2",
+			SessionID: "1",
 			Name:      "Test",
 		})
 
@@ -265,7 +1003,7 @@ func TestCreateCharacterAspect(t *testing.T) {
 			},
 		},
 	}
-	createAspect := ProvideCreateCharacterAspect(repo)
+	createAspect := ProvideCreateCharacterAspect(repo, Quotas{})
 
 	t.Run("not_found", func(t *testing.T) {
 		ctx := auth.WithUserID(context.Background(), "2")
@@ -288,7 +1026,7 @@ func TestCreateCharacterAspect(t *testing.T) {
 	t.Run("not_authorized", func(t *testing.T) {
 		aspectID, err := createAspect(context.Background(), CreateCharacterAspectRequest{
 			CreateAspectRequest: CreateAspectRequest{
-				SessionID: "2",
+				SessionID: "1",
 				Name:      "Test",
 			},
 			CharacterID: "3",
@@ -424,6 +1162,101 @@ func TestDeleteAspect(t *testing.T) {
 	})
 }
 
+func TestSetAspectVisibility(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Characters: []session.Character{
+				{ID: "3", OwnerID: "4"},
+			},
+			Aspects: session.Aspects{
+				{ID: "5", Visibility: session.Visibility{Level: session.GMOnly}},
+			},
+		},
+	}
+	setAspectVisibility := ProvideSetAspectVisibility(repo)
+
+	t.Run("not_gm", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "4")
+		err := setAspectVisibility(ctx, SetAspectVisibilityRequest{SessionID: "1", AspectID: "5"})
+		expect.That(t,
+			is.Error(err, ErrForbidden),
+			is.EqualTo(repo.s.Aspects[0].Visibility.Level, session.GMOnly),
+		)
+	})
+
+	t.Run("aspect_not_found", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := setAspectVisibility(ctx, SetAspectVisibilityRequest{SessionID: "1", AspectID: "6"})
+		expect.That(t, is.Error(err, ErrNotFound))
+	})
+
+	t.Run("invalid_character", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := setAspectVisibility(ctx, SetAspectVisibilityRequest{
+			SessionID:  "1",
+			AspectID:   "5",
+			Visibility: session.Visibility{Level: session.SelectedCharacters, CharacterIDs: []string{"6"}},
+		})
+		expect.That(t, is.Error(err, ErrInvalidCharacter))
+	})
+
+	t.Run("reveal", func(t *testing.T) {
+		ctx := auth.WithUserID(context.Background(), "2")
+		err := setAspectVisibility(ctx, SetAspectVisibilityRequest{SessionID: "1", AspectID: "5"})
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Aspects[0].Visibility.Level, session.Public),
+		)
+
+		got, err := ProvideLoadSession(repo)(auth.WithUserID(context.Background(), "4"), "1")
+		expect.That(t,
+			is.NoError(err),
+			is.SliceOfLen(got.Aspects, 1),
+		)
+	})
+}
+
+func TestSetCharacterVisibility(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Characters: []session.Character{
+				{ID: "3", OwnerID: "4"},
+				{ID: "5", OwnerID: "2", Type: session.NPC},
+			},
+		},
+	}
+	setCharacterVisibility := ProvideSetCharacterVisibility(repo)
+	ctx := auth.WithUserID(context.Background(), "2")
+
+	t.Run("not_npc", func(t *testing.T) {
+		err := setCharacterVisibility(ctx, SetCharacterVisibilityRequest{
+			SessionID:   "1",
+			CharacterID: "3",
+			Visibility:  session.Visibility{Level: session.GMOnly},
+		})
+		expect.That(t, is.Error(err, ErrInvalidCharacter))
+	})
+
+	t.Run("hide", func(t *testing.T) {
+		err := setCharacterVisibility(ctx, SetCharacterVisibilityRequest{
+			SessionID:   "1",
+			CharacterID: "5",
+			Visibility:  session.Visibility{Level: session.GMOnly},
+		})
+		expect.That(t, is.NoError(err))
+
+		got, err := ProvideLoadSession(repo)(auth.WithUserID(context.Background(), "4"), "1")
+		expect.That(t,
+			is.NoError(err),
+			is.SliceOfLen(got.Characters, 1),
+		)
+	})
+}
+
 func TestUpdateFatePoints(t *testing.T) {
 	repo := &repoMock{
 		s: session.Session{
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrValidation is a sentinel error value matching all ValidationErrors.
+var ErrValidation = errors.New("validation failed")
+
+// FieldError describes the validation failure of a single request field.
+type FieldError struct {
+	// Field names the invalid field using the name of the corresponding API field.
+	Field   string
+	Message string
+}
+
+// ValidationError is returned by use cases when a request fails validation. It lists all invalid fields.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString(ErrValidation.Error())
+	for i, f := range e.Fields {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		b.WriteString(f.Field)
+		b.WriteString(" ")
+		b.WriteString(f.Message)
+	}
+	return b.String()
+}
+
+// Is returns true if target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// validator collects field errors while validating a request.
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) fail(field, format string, args ...any) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// name validates a human readable name such as a title. Ignoring leading and trailing whitespace, value
+// must not be empty or contain control characters. The length of names is limited by Quotas.
+func (v *validator) name(field, value string) {
+	value = strings.TrimSpace(value)
+
+	if len(value) == 0 {
+		v.fail(field, "must not be empty")
+		return
+	}
+
+	if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+		v.fail(field, "must not contain control characters")
+	}
+}
+
+// err returns a *ValidationError if any field failed validation and nil otherwise.
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
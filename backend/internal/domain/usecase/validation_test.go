@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+)
+
+func TestValidator_name(t *testing.T) {
+	type test struct {
+		value string
+		errs  []FieldError
+	}
+
+	tests := []test{
+		{"Test", nil},
+		{"  Tést \t", nil},
+		{"", []FieldError{{"title", "must not be empty"}}},
+		{" \n ", []FieldError{{"title", "must not be empty"}}},
+		{"Te\x00st", []FieldError{{"title", "must not contain control characters"}}},
+	}
+
+	for _, test := range tests {
+		var v validator
+		v.name("title", test.value)
+
+		expect.WithMessage(t, "%q", test.value).That(is.DeepEqualTo(v.fields, test.errs))
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	repo := &repoMock{}
+	ctx := auth.WithUserID(context.Background(), "2")
+
+	createSession := Decorate(Chain{Validate}, "CreateSession", ProvideCreateSession(repo, Quotas{}))
+	_, err := createSession(ctx, CreateSessionRequest{Title: " "})
+
+	var validationErr *ValidationError
+	expect.That(t,
+		is.Error(err, ErrValidation),
+		is.EqualTo(errors.As(err, &validationErr), true),
+		is.EqualTo(err.Error(), "validation failed: title must not be empty"),
+		is.DeepEqualTo(repo.s, session.Session{}),
+	)
+
+	expect.That(t, is.DeepEqualTo(validationErr.Fields, []FieldError{{"title", "must not be empty"}}))
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+)
+
+// ErrPreconditionFailed is a sentinel error value returned when a session has been modified since the
+// version the caller expected.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+type expectedVersionsContextKeyType string
+
+const expectedVersionsContextKey expectedVersionsContextKeyType = "expectedVersions"
+
+// WithExpectedVersions creates a new context with ctx as it's parent holding the versions a session is
+// expected to have. Use cases modifying a session fail with ErrPreconditionFailed if the session's version
+// matches none of them.
+func WithExpectedVersions(ctx context.Context, versions ...uint64) context.Context {
+	return context.WithValue(ctx, expectedVersionsContextKey, versions)
+}
+
+// ExpectedVersions retrieves the versions set with WithExpectedVersions from ctx. ok is false if no versions
+// have been set.
+func ExpectedVersions(ctx context.Context) (versions []uint64, ok bool) {
+	versions, ok = ctx.Value(expectedVersionsContextKey).([]uint64)
+	return
+}
+
+type versionRecorderContextKeyType string
+
+const versionRecorderContextKey versionRecorderContextKeyType = "versionRecorder"
+
+// WithVersionRecorder creates a new context with ctx as it's parent which records the version of the session
+// after a unit of work has been performed in version. Repositories report the version using RecordVersion.
+func WithVersionRecorder(ctx context.Context, version *uint64) context.Context {
+	return context.WithValue(ctx, versionRecorderContextKey, version)
+}
+
+// RecordVersion records version as the session's version after a unit of work performed with ctx. It is a
+// no-op unless ctx has been created by WithVersionRecorder.
+func RecordVersion(ctx context.Context, version uint64) {
+	if v, ok := ctx.Value(versionRecorderContextKey).(*uint64); ok {
+		*v = version
+	}
+}
+
+// perform performs uow using r after checking the session's version against the versions expected by ctx.
+func perform(ctx context.Context, r SessionRepository, id string, uow UnitOfWork) error {
+	return r.Perform(ctx, id, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+		if versions, ok := ExpectedVersions(ctx); ok && exists && !slices.Contains(versions, s.Version) {
+			return s, ErrPreconditionFailed
+		}
+
+		return uow(ctx, exists, s)
+	})
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+)
+
+func TestExpectedVersions(t *testing.T) {
+	repo := &repoMock{
+		s: session.Session{
+			ID:      "1",
+			OwnerID: "2",
+			Title:   "Test",
+			Version: 3,
+		},
+	}
+	updateSession := ProvideUpdateSession(repo, Quotas{})
+	ctx := auth.WithUserID(context.Background(), "2")
+
+	t.Run("stale", func(t *testing.T) {
+		err := updateSession(WithExpectedVersions(ctx, 1, 2), UpdateSessionRequest{SessionID: "1", Title: "Renamed"})
+		expect.That(t,
+			is.Error(err, ErrPreconditionFailed),
+			is.EqualTo(repo.s.Title, "Test"),
+		)
+	})
+
+	t.Run("current", func(t *testing.T) {
+		err := updateSession(WithExpectedVersions(ctx, 2, 3), UpdateSessionRequest{SessionID: "1", Title: "Renamed"})
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(repo.s.Title, "Renamed"),
+		)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		err := updateSession(WithExpectedVersions(ctx, 3), UpdateSessionRequest{SessionID: "2", Title: "Renamed"})
+		expect.That(t, is.Error(err, ErrNotFound))
+	})
+}
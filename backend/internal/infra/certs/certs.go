@@ -0,0 +1,56 @@
+// Package certs provides TLS certificates loaded from files which can be reloaded without restarting the
+// server.
+package certs
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sync/atomic"
+)
+
+// Reloader holds a certificate loaded from a certificate and key file. It serves the most recently loaded
+// certificate to TLS handshakes.
+type Reloader struct {
+	certFile, keyFile string
+	cert              atomic.Pointer[tls.Certificate]
+}
+
+// NewReloader creates a Reloader and loads the certificate from certFile and keyFile.
+func NewReloader(certFile, keyFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload loads the certificate from the files again. If loading fails, the previous certificate is kept.
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	r.cert.Store(&cert)
+	return nil
+}
+
+// GetCertificate returns the current certificate. It implements tls.Config.GetCertificate.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.cert.Load(), nil
+}
+
+// ServerConfig returns a TLS configuration serving r's current certificate. HTTP/2 is negotiated by
+// http.Server when serving TLS with this configuration.
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+}
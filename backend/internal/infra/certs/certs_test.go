@@ -0,0 +1,118 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+// writeSelfSigned generates a self-signed certificate for localhost with the given common name and writes
+// it along with its key to dir. It returns the certificate.
+func writeSelfSigned(t *testing.T, dir, commonName string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:     []string{"localhost"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	expect.That(t, expect.FailNow(
+		is.NoError(os.WriteFile(filepath.Join(dir, "cert.pem"), certPEM, 0o600)),
+		is.NoError(os.WriteFile(filepath.Join(dir, "key.pem"), keyPEM, 0o600)),
+	))
+
+	cert, err := x509.ParseCertificate(der)
+	expect.That(t, expect.FailNow(is.NoError(err)))
+	return cert
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	writeSelfSigned(t, dir, "first")
+
+	r, err := NewReloader(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"))
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	cert, _ := r.GetCertificate(nil)
+	expect.That(t, is.EqualTo(cert.Leaf.Subject.CommonName, "first"))
+
+	writeSelfSigned(t, dir, "second")
+	expect.That(t, is.NoError(r.Reload()))
+
+	cert, _ = r.GetCertificate(nil)
+	expect.That(t, is.EqualTo(cert.Leaf.Subject.CommonName, "second"))
+
+	// A failed reload keeps the previous certificate.
+	expect.That(t, expect.FailNow(is.NoError(os.WriteFile(filepath.Join(dir, "key.pem"), []byte("invalid"), 0o600))))
+	expect.That(t, is.EqualTo(r.Reload() != nil, true))
+
+	cert, _ = r.GetCertificate(nil)
+	expect.That(t, is.EqualTo(cert.Leaf.Subject.CommonName, "second"))
+}
+
+func TestReloader_ServerConfig(t *testing.T) {
+	dir := t.TempDir()
+	leaf := writeSelfSigned(t, dir, "localhost")
+
+	r, err := NewReloader(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"))
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}),
+		TLSConfig: r.ServerConfig(),
+	}
+	go srv.ServeTLS(ln, "", "")
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: roots},
+			ForceAttemptHTTP2: true,
+		},
+	}
+
+	res, err := client.Get("https://" + ln.Addr().String() + "/")
+	expect.That(t, expect.FailNow(is.NoError(err)))
+	defer res.Body.Close()
+
+	expect.That(t,
+		is.EqualTo(res.StatusCode, http.StatusNoContent),
+		is.EqualTo(res.ProtoMajor, 2),
+	)
+}
@@ -1,25 +1,175 @@
+// Package config provides the service's configuration. Configuration values are read from environment
+// variables and an optional YAML configuration file using the environment variables' names as keys.
+// Environment variables take precedence over the configuration file which takes precedence over the
+// defaults.
 package config
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
 )
 
 type Config struct {
-	DevMode         bool          `env:"DEV_MODE,default=0"`
-	HTTPPort        int           `env:"HTTP_PORT,default=8080"`
-	AuthTokenSecret string        `env:"AUTH_TOKEN_SECRET,default=secret"`
+	DevMode  bool `env:"DEV_MODE,default=0"`
+	HTTPPort int  `env:"HTTP_PORT,default=8080"`
+	// TLSCertFile and TLSKeyFile name PEM encoded files containing the certificate and private key used to
+	// serve HTTPS on HTTPPort. Both must be set to enable TLS. The files are read again on SIGHUP.
+	TLSCertFile string `env:"TLS_CERT_FILE"`
+	TLSKeyFile  string `env:"TLS_KEY_FILE"`
+	// HTTPRedirectPort defines a port on which plain HTTP requests are redirected to HTTPS. Requires TLS. A
+	// value of 0 disables the redirect listener.
+	HTTPRedirectPort int `env:"HTTP_REDIRECT_PORT,default=0"`
+	// MetricsPort defines the port of the admin listener exposing metrics at /metrics. The listener must not
+	// be reachable from the public network. A value of 0 disables the admin listener.
+	MetricsPort int `env:"METRICS_PORT,default=0"`
+	// ShutdownDelay defines how long the service reports not being ready before it stops accepting
+	// connections, giving load balancers time to stop routing traffic to it.
+	ShutdownDelay time.Duration `env:"SHUTDOWN_DELAY,default=0s"`
+	// ShutdownTimeout defines how long in-flight requests may take to complete during shutdown before their
+	// connections are closed.
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT,default=30s"`
+	// UseCaseTimeout limits the time a single use case invocation may take. A value of 0 disables the
+	// timeout.
+	UseCaseTimeout time.Duration `env:"USECASE_TIMEOUT,default=10s"`
+	// SessionStore selects the repository used to store sessions. Must be one of "memory", "events" or
+	// "file".
+	SessionStore string `env:"SESSION_STORE,default=memory"`
+	// SessionFile names the file sessions are persisted to if SessionStore is "file".
+	SessionFile string `env:"SESSION_FILE,default=sessions.json"`
+	// EventSnapshotInterval defines the number of events after which the event store takes a snapshot of a
+	// session. A value of 0 disables snapshots.
+	EventSnapshotInterval int `env:"EVENT_SNAPSHOT_INTERVAL,default=100"`
+	// AuthTokenSecret is the secret used to sign authentication tokens. The default is only accepted in
+	// dev mode.
+	AuthTokenSecret string        `env:"AUTH_TOKEN_SECRET,default=secret" secret:"true"`
 	AuthTokenTTL    time.Duration `env:"AUTH_TOKEN_TTL,default=240m"`
+	// SessionIdleTTL defines the duration after which sessions that have not been modified expire. A value of
+	// 0 disables session expiry.
+	SessionIdleTTL time.Duration `env:"SESSION_IDLE_TTL,default=168h"`
+	// SessionExpiryAction defines what happens to expired sessions. Must be one of "delete" or "archive".
+	SessionExpiryAction string `env:"SESSION_EXPIRY_ACTION,default=delete"`
+	// SessionArchiveTTL defines the duration after which archived sessions that have not been modified are
+	// deleted if SessionExpiryAction is "archive". A value of 0 keeps archived sessions forever.
+	SessionArchiveTTL time.Duration `env:"SESSION_ARCHIVE_TTL,default=720h"`
+	JanitorInterval   time.Duration `env:"JANITOR_INTERVAL,default=10m"`
+	// UndoHistorySize defines the number of changes per session that can be undone. A value of 0 disables
+	// undo.
+	UndoHistorySize int `env:"UNDO_HISTORY_SIZE,default=20"`
+	// Quotas limit the resources a single user or session may consume. A value of 0 disables the limit.
+	MaxSessionsPerUser      int `env:"MAX_SESSIONS_PER_USER,default=10"`
+	MaxCharactersPerSession int `env:"MAX_CHARACTERS_PER_SESSION,default=20"`
+	MaxAspectsPerSession    int `env:"MAX_ASPECTS_PER_SESSION,default=200"`
+	MaxNameLength           int `env:"MAX_NAME_LENGTH,default=100"`
+	// Rate limits define the number of requests per minute and the burst size allowed per user (or client IP
+	// for unauthenticated requests) for each group of routes. A rate of 0 disables rate limiting.
+	RateLimitAuthPerMinute      int `env:"RATE_LIMIT_AUTH_PER_MINUTE,default=10"`
+	RateLimitAuthBurst          int `env:"RATE_LIMIT_AUTH_BURST,default=5"`
+	RateLimitMutationsPerMinute int `env:"RATE_LIMIT_MUTATIONS_PER_MINUTE,default=120"`
+	RateLimitMutationsBurst     int `env:"RATE_LIMIT_MUTATIONS_BURST,default=30"`
+	RateLimitReadsPerMinute     int `env:"RATE_LIMIT_READS_PER_MINUTE,default=300"`
+	RateLimitReadsBurst         int `env:"RATE_LIMIT_READS_BURST,default=60"`
+	// TrustedProxies lists the IP addresses or CIDR ranges, separated by commas, of reverse proxies whose
+	// X-Forwarded-For header is used to determine the client IP for rate limiting. An empty value uses the
+	// connection's remote address.
+	TrustedProxies string `env:"TRUSTED_PROXIES"`
+	// IdempotencyKeyTTL defines how long responses to requests carrying an Idempotency-Key header are kept
+	// for replay. A value of 0 disables idempotency key support.
+	IdempotencyKeyTTL time.Duration `env:"IDEMPOTENCY_KEY_TTL,default=24h"`
+	// CORSAllowedOrigins lists the origins, separated by commas, from which browsers may call the API, e.g.
+	// https://tools.example.com. An empty value disallows cross-origin requests.
+	CORSAllowedOrigins string `env:"CORS_ALLOWED_ORIGINS"`
+	// TracingEndpoint defines the URL of an OTLP/HTTP collector spans are exported to, e.g.
+	// http://localhost:4318/v1/traces. An empty value disables tracing.
+	TracingEndpoint string `env:"TRACING_ENDPOINT"`
+	// TracingSampleRatio defines the fraction of traces started by this service which are recorded.
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO,default=1"`
 }
 
-func Provide(ctx context.Context) Config {
+const (
+	SessionStoreMemory = "memory"
+	SessionStoreEvents = "events"
+	SessionStoreFile   = "file"
+)
+
+const (
+	SessionExpiryDelete  = "delete"
+	SessionExpiryArchive = "archive"
+)
+
+// TLSEnabled reports whether c configures the service to serve HTTPS.
+func (c Config) TLSEnabled() bool {
+	return len(c.TLSCertFile) > 0 && len(c.TLSKeyFile) > 0
+}
+
+// CORSOrigins returns the origins listed in CORSAllowedOrigins.
+func (c Config) CORSOrigins() []string {
+	var origins []string
+	for _, o := range strings.Split(c.CORSAllowedOrigins, ",") {
+		if o = strings.TrimSpace(o); len(o) > 0 {
+			origins = append(origins, o)
+		}
+	}
+	return origins
+}
+
+// TrustedProxyPrefixes returns the addresses listed in TrustedProxies. Single IP addresses are returned as
+// prefixes containing only that address. Invalid entries are skipped.
+func (c Config) TrustedProxyPrefixes() []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, p := range strings.Split(c.TrustedProxies, ",") {
+		if p = strings.TrimSpace(p); len(p) == 0 {
+			continue
+		}
+		if prefix, err := parsePrefix(p); err == nil {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// parsePrefix parses s as either a CIDR range or a single IP address.
+func parsePrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		return netip.ParsePrefix(s)
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// DefaultAuthTokenSecret is the AuthTokenSecret used if none is configured.
+const DefaultAuthTokenSecret = "secret"
+
+// Load loads the configuration from environment variables and the YAML file named by file. If file is
+// empty, only environment variables are used. Load returns a *ValidationError listing all problems found
+// if the configuration is invalid.
+func Load(ctx context.Context, file string) (Config, error) {
+	lookuper := envconfig.OsLookuper()
+
+	if len(file) > 0 {
+		values, err := readFile(file)
+		if err != nil {
+			return Config{}, err
+		}
+		lookuper = envconfig.MultiLookuper(lookuper, envconfig.MapLookuper(values))
+	}
+
 	var c Config
+	if err := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &c, Lookuper: lookuper}); err != nil {
+		return Config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
 
-	if err := envconfig.Process(ctx, &c); err != nil {
-		panic(err)
+	if err := c.Validate(); err != nil {
+		return Config{}, err
 	}
 
-	return c
+	return c, nil
 }
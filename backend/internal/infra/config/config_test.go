@@ -0,0 +1,149 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_precedence(t *testing.T) {
+	file := writeFile(t, "config.yaml", `
+HTTP_PORT: 9090
+auth_token_secret: from-file
+AUTH_TOKEN_TTL: 1h
+UNDO_HISTORY_SIZE: 0
+`)
+	t.Setenv("AUTH_TOKEN_TTL", "2h")
+
+	cfg, err := Load(context.Background(), file)
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(cfg.HTTPPort, 9090),
+		is.EqualTo(cfg.AuthTokenSecret, "from-file"),
+		is.EqualTo(cfg.AuthTokenTTL, 2*time.Hour),
+		is.EqualTo(cfg.UndoHistorySize, 0),
+		is.EqualTo(cfg.MaxNameLength, 100),
+	)
+}
+
+func TestLoad_invalidFile(t *testing.T) {
+	file := writeFile(t, "config.yaml", `
+HTTP_PORTS: 9090
+RATE_LIMIT_AUTH_BURST: [1, 2]
+`)
+
+	_, err := Load(context.Background(), file)
+
+	var validationErr *ValidationError
+	expect.That(t, is.EqualTo(errors.As(err, &validationErr), true))
+	expect.That(t, is.DeepEqualTo(validationErr.Problems, []Problem{
+		{Key: "HTTP_PORTS", Message: "unknown configuration key"},
+		{Key: "RATE_LIMIT_AUTH_BURST", Message: "must be a scalar value"},
+	}))
+
+	_, err = Load(context.Background(), writeFile(t, "config.toml", ""))
+	expect.That(t, is.EqualTo(err != nil, true))
+}
+
+func TestValidate(t *testing.T) {
+	t.Setenv("HTTP_PORT", "70000")
+	t.Setenv("AUTH_TOKEN_TTL", "10s")
+	t.Setenv("SESSION_STORE", "disk")
+
+	_, err := Load(context.Background(), "")
+
+	var validationErr *ValidationError
+	expect.That(t, is.EqualTo(errors.As(err, &validationErr), true))
+	expect.That(t, is.DeepEqualTo(validationErr.Problems, []Problem{
+		{Key: "AUTH_TOKEN_SECRET", Message: "must be changed from the default unless DEV_MODE is enabled"},
+		{Key: "AUTH_TOKEN_TTL", Message: "must be between 1m0s and 720h0m0s"},
+		{Key: "HTTP_PORT", Message: "must be between 1 and 65535"},
+		{Key: "SESSION_STORE", Message: `must be one of "memory", "events" or "file"`},
+	}))
+	expect.That(t, is.EqualTo(strings.HasPrefix(err.Error(), "invalid configuration:\n  AUTH_TOKEN_SECRET: "), true))
+}
+
+func TestValidate_devMode(t *testing.T) {
+	t.Setenv("DEV_MODE", "1")
+
+	_, err := Load(context.Background(), "")
+	expect.That(t, is.NoError(err))
+}
+
+func TestValidate_tlsAndCORS(t *testing.T) {
+	t.Setenv("DEV_MODE", "1")
+	t.Setenv("TLS_CERT_FILE", "cert.pem")
+	t.Setenv("HTTP_REDIRECT_PORT", "8080")
+	t.Setenv("METRICS_PORT", "8080")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://tools.example.com, https://example.com/app")
+
+	_, err := Load(context.Background(), "")
+
+	var validationErr *ValidationError
+	expect.That(t, is.EqualTo(errors.As(err, &validationErr), true))
+	expect.That(t, is.DeepEqualTo(validationErr.Problems, []Problem{
+		{Key: "CORS_ALLOWED_ORIGINS", Message: `"https://example.com/app" is not an origin of the form scheme://host[:port]`},
+		{Key: "HTTP_REDIRECT_PORT", Message: "must differ from HTTP_PORT"},
+		{Key: "METRICS_PORT", Message: "must differ from HTTP_PORT and HTTP_REDIRECT_PORT"},
+		{Key: "TLS_KEY_FILE", Message: "must be set if TLS_CERT_FILE is set"},
+	}))
+}
+
+func TestValidate_trustedProxies(t *testing.T) {
+	t.Setenv("DEV_MODE", "1")
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.1, 192.168.0.0/16, ::1, proxy.local")
+
+	_, err := Load(context.Background(), "")
+
+	var validationErr *ValidationError
+	expect.That(t, is.EqualTo(errors.As(err, &validationErr), true))
+	expect.That(t,
+		is.DeepEqualTo(validationErr.Problems, []Problem{
+			{Key: "TRUSTED_PROXIES", Message: `"proxy.local" is not an IP address or CIDR range`},
+		}),
+		is.SliceOfLen(Config{TrustedProxies: os.Getenv("TRUSTED_PROXIES")}.TrustedProxyPrefixes(), 3),
+	)
+}
+
+func TestPrint(t *testing.T) {
+	t.Setenv("DEV_MODE", "1")
+	t.Setenv("AUTH_TOKEN_SECRET", "top-secret")
+
+	cfg, err := Load(context.Background(), "")
+	expect.That(t, is.NoError(err))
+
+	var buf bytes.Buffer
+	expect.That(t, is.NoError(Print(&buf, cfg)))
+
+	out := buf.String()
+	expect.That(t,
+		is.EqualTo(strings.Contains(out, "top-secret"), false),
+		is.EqualTo(strings.Contains(out, "AUTH_TOKEN_SECRET: <redacted>\n"), true),
+		is.EqualTo(strings.Contains(out, "AUTH_TOKEN_TTL: 4h0m0s\n"), true),
+		is.EqualTo(strings.Contains(out, "HTTP_PORT: 8080\n"), true),
+	)
+
+	// The printed configuration can be read back as a configuration file.
+	file := writeFile(t, "config.yaml", strings.ReplaceAll(out, redacted, "top-secret"))
+	reloaded, err := Load(context.Background(), file)
+	expect.That(t,
+		is.NoError(err),
+		is.DeepEqualTo(reloaded, cfg),
+	)
+}
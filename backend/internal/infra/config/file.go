@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// field describes a single configuration value.
+type field struct {
+	// key is the name of the environment variable and the configuration file key.
+	key    string
+	secret bool
+	index  int
+}
+
+// fields returns the configuration values defined by Config in declaration order.
+func fields() []field {
+	t := reflect.TypeOf(Config{})
+	fs := make([]field, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := t.Field(i).Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+
+		key, _, _ := strings.Cut(tag, ",")
+		fs = append(fs, field{
+			key:    key,
+			secret: t.Field(i).Tag.Get("secret") == "true",
+			index:  i,
+		})
+	}
+
+	return fs
+}
+
+// readFile reads the configuration file named name. The file must contain a YAML mapping of configuration
+// keys to scalar values. Keys are matched case-insensitively. JSON files are accepted, too.
+func readFile(name string) (map[string]string, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+	default:
+		return nil, fmt.Errorf("unsupported configuration file format: %s", name)
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file %s: %w", name, err)
+	}
+
+	known := make(map[string]bool)
+	for _, f := range fields() {
+		known[f.key] = true
+	}
+
+	values := make(map[string]string, len(raw))
+	var v ValidationError
+
+	for k, val := range raw {
+		key := strings.ToUpper(k)
+		if !known[key] {
+			v.fail(k, "unknown configuration key")
+			continue
+		}
+
+		switch val.(type) {
+		case map[string]any, []any:
+			v.fail(key, "must be a scalar value")
+			continue
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = fmt.Sprint(val)
+		}
+	}
+
+	if err := v.err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return values, nil
+}
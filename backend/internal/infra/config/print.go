@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces the values of secrets when printing a configuration.
+const redacted = "<redacted>"
+
+// Print writes c to w in the format of a configuration file with all secrets redacted.
+func Print(w io.Writer, c Config) error {
+	v := reflect.ValueOf(c)
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, f := range fields() {
+		value := fmt.Sprint(v.Field(f.index).Interface())
+		if d, ok := v.Field(f.index).Interface().(time.Duration); ok {
+			value = d.String()
+		}
+		if f.secret {
+			value = redacted
+		}
+
+		doc.Content = append(doc.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: f.key},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+		)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
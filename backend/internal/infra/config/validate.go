@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Problem describes a single invalid configuration value.
+type Problem struct {
+	// Key names the invalid value using the environment variable's name.
+	Key     string
+	Message string
+}
+
+// ValidationError is returned when the configuration is invalid. It lists all problems found.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid configuration:")
+	for _, p := range e.Problems {
+		b.WriteString("\n  ")
+		b.WriteString(p.Key)
+		b.WriteString(": ")
+		b.WriteString(p.Message)
+	}
+	return b.String()
+}
+
+func (e *ValidationError) fail(key, format string, args ...any) {
+	e.Problems = append(e.Problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns e if any problem has been found and nil otherwise.
+func (e *ValidationError) err() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(e.Problems, func(a, b Problem) int { return strings.Compare(a.Key, b.Key) })
+	return e
+}
+
+// Validate validates c and returns a *ValidationError listing all problems found.
+func (c Config) Validate() error {
+	var v ValidationError
+
+	if c.HTTPPort < 1 || c.HTTPPort > 65535 {
+		v.fail("HTTP_PORT", "must be between 1 and 65535")
+	}
+
+	if len(c.TLSCertFile) > 0 && len(c.TLSKeyFile) == 0 {
+		v.fail("TLS_KEY_FILE", "must be set if TLS_CERT_FILE is set")
+	}
+	if len(c.TLSKeyFile) > 0 && len(c.TLSCertFile) == 0 {
+		v.fail("TLS_CERT_FILE", "must be set if TLS_KEY_FILE is set")
+	}
+
+	if c.HTTPRedirectPort != 0 {
+		switch {
+		case c.HTTPRedirectPort < 1 || c.HTTPRedirectPort > 65535:
+			v.fail("HTTP_REDIRECT_PORT", "must be 0 or between 1 and 65535")
+		case c.HTTPRedirectPort == c.HTTPPort:
+			v.fail("HTTP_REDIRECT_PORT", "must differ from HTTP_PORT")
+		case !c.TLSEnabled():
+			v.fail("HTTP_REDIRECT_PORT", "requires TLS_CERT_FILE and TLS_KEY_FILE")
+		}
+	}
+
+	if c.MetricsPort != 0 {
+		switch {
+		case c.MetricsPort < 1 || c.MetricsPort > 65535:
+			v.fail("METRICS_PORT", "must be 0 or between 1 and 65535")
+		case c.MetricsPort == c.HTTPPort || c.MetricsPort == c.HTTPRedirectPort:
+			v.fail("METRICS_PORT", "must differ from HTTP_PORT and HTTP_REDIRECT_PORT")
+		}
+	}
+
+	if !c.DevMode && c.AuthTokenSecret == DefaultAuthTokenSecret {
+		v.fail("AUTH_TOKEN_SECRET", "must be changed from the default unless DEV_MODE is enabled")
+	}
+
+	if len(c.AuthTokenSecret) == 0 {
+		v.fail("AUTH_TOKEN_SECRET", "must not be empty")
+	}
+
+	v.between("AUTH_TOKEN_TTL", c.AuthTokenTTL, time.Minute, 30*24*time.Hour)
+	v.between("JANITOR_INTERVAL", c.JanitorInterval, time.Second, 24*time.Hour)
+	v.between("SHUTDOWN_TIMEOUT", c.ShutdownTimeout, time.Second, time.Hour)
+	v.disabledOrBetween("SESSION_IDLE_TTL", c.SessionIdleTTL, time.Minute, 365*24*time.Hour)
+	v.disabledOrBetween("SESSION_ARCHIVE_TTL", c.SessionArchiveTTL, time.Minute, 365*24*time.Hour)
+	v.disabledOrBetween("IDEMPOTENCY_KEY_TTL", c.IdempotencyKeyTTL, time.Minute, 7*24*time.Hour)
+	v.disabledOrBetween("USECASE_TIMEOUT", c.UseCaseTimeout, 10*time.Millisecond, time.Hour)
+	v.disabledOrBetween("SHUTDOWN_DELAY", c.ShutdownDelay, 0, time.Hour)
+
+	switch c.SessionStore {
+	case SessionStoreMemory, SessionStoreEvents:
+	case SessionStoreFile:
+		if len(c.SessionFile) == 0 {
+			v.fail("SESSION_FILE", "must not be empty")
+		}
+	default:
+		v.fail("SESSION_STORE", "must be one of %q, %q or %q", SessionStoreMemory, SessionStoreEvents,
+			SessionStoreFile)
+	}
+
+	if c.SessionExpiryAction != SessionExpiryDelete && c.SessionExpiryAction != SessionExpiryArchive {
+		v.fail("SESSION_EXPIRY_ACTION", "must be one of %q or %q", SessionExpiryDelete, SessionExpiryArchive)
+	}
+
+	for _, origin := range c.CORSOrigins() {
+		if !validOrigin(origin) {
+			v.fail("CORS_ALLOWED_ORIGINS", "%q is not an origin of the form scheme://host[:port]", origin)
+		}
+	}
+
+	for _, p := range strings.Split(c.TrustedProxies, ",") {
+		if p = strings.TrimSpace(p); len(p) == 0 {
+			continue
+		}
+		if _, err := parsePrefix(p); err != nil {
+			v.fail("TRUSTED_PROXIES", "%q is not an IP address or CIDR range", p)
+		}
+	}
+
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		v.fail("TRACING_SAMPLE_RATIO", "must be between 0 and 1")
+	}
+
+	for key, n := range map[string]int{
+		"EVENT_SNAPSHOT_INTERVAL":         c.EventSnapshotInterval,
+		"UNDO_HISTORY_SIZE":               c.UndoHistorySize,
+		"MAX_SESSIONS_PER_USER":           c.MaxSessionsPerUser,
+		"MAX_CHARACTERS_PER_SESSION":      c.MaxCharactersPerSession,
+		"MAX_ASPECTS_PER_SESSION":         c.MaxAspectsPerSession,
+		"MAX_NAME_LENGTH":                 c.MaxNameLength,
+		"RATE_LIMIT_AUTH_PER_MINUTE":      c.RateLimitAuthPerMinute,
+		"RATE_LIMIT_AUTH_BURST":           c.RateLimitAuthBurst,
+		"RATE_LIMIT_MUTATIONS_PER_MINUTE": c.RateLimitMutationsPerMinute,
+		"RATE_LIMIT_MUTATIONS_BURST":      c.RateLimitMutationsBurst,
+		"RATE_LIMIT_READS_PER_MINUTE":     c.RateLimitReadsPerMinute,
+		"RATE_LIMIT_READS_BURST":          c.RateLimitReadsBurst,
+	} {
+		if n < 0 {
+			v.fail(key, "must not be negative")
+		}
+	}
+
+	return v.err()
+}
+
+// validOrigin reports whether origin is a serialized HTTP(S) origin as sent by browsers in the Origin header.
+func validOrigin(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && len(u.Host) > 0 && u.String() == u.Scheme+"://"+u.Host
+}
+
+// between validates that d lies within [lo, hi].
+func (e *ValidationError) between(key string, d, lo, hi time.Duration) {
+	if d < lo || d > hi {
+		e.fail(key, "must be between %s and %s", lo, hi)
+	}
+}
+
+// disabledOrBetween validates that d is either 0 or lies within [lo, hi].
+func (e *ValidationError) disabledOrBetween(key string, d, lo, hi time.Duration) {
+	if d != 0 {
+		e.between(key, d, lo, hi)
+	}
+}
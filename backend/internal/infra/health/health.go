@@ -0,0 +1,124 @@
+// Package health implements a registry of checks reporting the service's operational state for use by
+// liveness and readiness probes.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// checkTimeout limits the time a single check may take.
+const checkTimeout = 2 * time.Second
+
+// ErrDraining is reported by the readiness probe while the service is draining.
+var ErrDraining = errors.New("draining")
+
+// Check checks the state of a single subsystem. It returns nil if the subsystem is healthy.
+type Check func(ctx context.Context) error
+
+// Result contains the outcome of a single check. Err is nil if the check passed.
+type Result struct {
+	Name string
+	Err  error
+}
+
+// Report contains the results of all checks performed by a probe.
+type Report struct {
+	Results []Result
+}
+
+// OK returns true if all checks passed.
+func (r Report) OK() bool {
+	for _, res := range r.Results {
+		if res.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Registry holds the checks registered by subsystems. Liveness checks detect failures that require a
+// restart of the service; readiness checks detect conditions in which the service should not receive
+// traffic. The zero value is ready to use.
+type Registry struct {
+	lock      sync.RWMutex
+	liveness  []namedCheck
+	readiness []namedCheck
+	draining  atomic.Bool
+}
+
+// AddLivenessCheck registers check under name to be performed by the liveness probe.
+func (r *Registry) AddLivenessCheck(name string, check Check) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.liveness = append(r.liveness, namedCheck{name: name, check: check})
+}
+
+// AddReadinessCheck registers check under name to be performed by the readiness probe.
+func (r *Registry) AddReadinessCheck(name string, check Check) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.readiness = append(r.readiness, namedCheck{name: name, check: check})
+}
+
+// Drain marks the service as draining. A draining service is no longer ready to accept new traffic while
+// it completes in-flight requests.
+func (r *Registry) Drain() {
+	r.draining.Store(true)
+}
+
+// Liveness performs all liveness checks.
+func (r *Registry) Liveness(ctx context.Context) Report {
+	r.lock.RLock()
+	checks := r.liveness
+	r.lock.RUnlock()
+
+	return perform(ctx, checks)
+}
+
+// Readiness performs all readiness checks. It always reports a failing "draining" check once Drain has been
+// called.
+func (r *Registry) Readiness(ctx context.Context) Report {
+	r.lock.RLock()
+	checks := r.readiness
+	r.lock.RUnlock()
+
+	checks = append([]namedCheck{{name: "draining", check: func(context.Context) error {
+		if r.draining.Load() {
+			return ErrDraining
+		}
+		return nil
+	}}}, checks...)
+
+	return perform(ctx, checks)
+}
+
+// perform performs checks concurrently.
+func perform(ctx context.Context, checks []namedCheck) Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = Result{Name: c.name, Err: c.check(ctx)}
+		}()
+	}
+	wg.Wait()
+
+	return Report{Results: results}
+}
@@ -0,0 +1,45 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestRegistry(t *testing.T) {
+	var r Registry
+
+	failure := errors.New("failure")
+
+	r.AddLivenessCheck("ok", func(context.Context) error { return nil })
+	r.AddReadinessCheck("failing", func(context.Context) error { return failure })
+
+	live := r.Liveness(context.Background())
+	expect.That(t,
+		is.EqualTo(live.OK(), true),
+		is.DeepEqualTo(live.Results, []Result{{Name: "ok"}}),
+	)
+
+	ready := r.Readiness(context.Background())
+	expect.That(t,
+		is.EqualTo(ready.OK(), false),
+		is.DeepEqualTo(ready.Results, []Result{{Name: "draining"}, {Name: "failing", Err: failure}}),
+	)
+}
+
+func TestRegistry_Drain(t *testing.T) {
+	var r Registry
+	expect.That(t, is.EqualTo(r.Readiness(context.Background()).OK(), true))
+
+	r.Drain()
+
+	ready := r.Readiness(context.Background())
+	expect.That(t,
+		is.EqualTo(ready.OK(), false),
+		is.Error(ready.Results[0].Err, ErrDraining),
+		is.EqualTo(r.Liveness(context.Background()).OK(), true),
+	)
+}
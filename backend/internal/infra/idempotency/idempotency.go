@@ -0,0 +1,120 @@
+// Package idempotency implements a store for responses of requests carrying an idempotency key so that
+// retried requests can be answered by replaying the original response.
+package idempotency
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// sweepInterval defines how often expired entries are removed.
+const sweepInterval = time.Minute
+
+// Response is a recorded response.
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// State describes the result of reserving a key.
+type State int
+
+const (
+	// Reserved signals that the key has not been seen before. The caller must process the request and either
+	// complete or release the key.
+	Reserved State = iota
+	// Completed signals that a response has been recorded for the key and should be replayed.
+	Completed
+	// InFlight signals that a request with the same key is currently being processed.
+	InFlight
+	// Mismatch signals that the key has been used with a different request.
+	Mismatch
+)
+
+type entry struct {
+	fingerprint string
+	response    *Response
+	expires     time.Time
+}
+
+// Store holds recorded responses for a limited time.
+type Store struct {
+	ttl time.Duration
+	now func() time.Time
+
+	lock      sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+// New creates a new Store keeping responses for ttl.
+func New(ttl time.Duration) *Store {
+	return &Store{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*entry),
+	}
+}
+
+// Reserve reserves key for a request identified by fingerprint. The recorded response is returned along with
+// Completed if the key has been completed with the same fingerprint before.
+func (s *Store) Reserve(key, fingerprint string) (State, *Response) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	e, ok := s.entries[key]
+	if !ok || now.After(e.expires) {
+		s.entries[key] = &entry{
+			fingerprint: fingerprint,
+			expires:     now.Add(s.ttl),
+		}
+		return Reserved, nil
+	}
+
+	if e.fingerprint != fingerprint {
+		return Mismatch, nil
+	}
+
+	if e.response == nil {
+		return InFlight, nil
+	}
+
+	return Completed, e.response
+}
+
+// Complete records res for the previously reserved key.
+func (s *Store) Complete(key string, res Response) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		e.response = &res
+		e.expires = s.now().Add(s.ttl)
+	}
+}
+
+// Release removes the reservation for key so that the request may be retried.
+func (s *Store) Release(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.entries, key)
+}
+
+func (s *Store) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, e := range s.entries {
+		if now.After(e.expires) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestStore(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := New(time.Hour)
+	s.now = func() time.Time { return now }
+
+	state, _ := s.Reserve("a", "1")
+	expect.That(t, is.EqualTo(state, Reserved))
+
+	state, _ = s.Reserve("a", "1")
+	expect.That(t, is.EqualTo(state, InFlight))
+
+	state, _ = s.Reserve("a", "2")
+	expect.That(t, is.EqualTo(state, Mismatch))
+
+	s.Complete("a", Response{Status: 201, Body: []byte("{}")})
+
+	state, res := s.Reserve("a", "1")
+	expect.That(t,
+		is.EqualTo(state, Completed),
+		is.DeepEqualTo(res, &Response{Status: 201, Body: []byte("{}")}),
+	)
+
+	now = now.Add(time.Hour + time.Second)
+
+	state, _ = s.Reserve("a", "2")
+	expect.That(t, is.EqualTo(state, Reserved))
+}
+
+func TestStore_Release(t *testing.T) {
+	s := New(time.Hour)
+
+	s.Reserve("a", "1")
+	s.Release("a")
+
+	state, _ := s.Reserve("a", "1")
+	expect.That(t, is.EqualTo(state, Reserved))
+}
+
+func TestStore_sweep(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := New(time.Minute)
+	s.now = func() time.Time { return now }
+
+	s.Reserve("a", "1")
+
+	now = now.Add(sweepInterval + time.Second)
+	s.Reserve("b", "1")
+
+	expect.That(t, is.EqualTo(len(s.entries), 1))
+}
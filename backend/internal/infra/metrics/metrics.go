@@ -0,0 +1,31 @@
+// Package metrics provides the registry application metrics are registered with and exposes them using the
+// Prometheus exposition format.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry is the prometheus.Registry used by the application. Besides the application's metrics it collects
+// Go runtime and process metrics.
+var Registry = prometheus.NewRegistry()
+
+// Default creates metrics registered with Registry.
+var Default = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler returns a http.Handler exposing all metrics registered with Registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestHandler(t *testing.T) {
+	Default.NewCounter(prometheus.CounterOpts{Name: "test_total", Help: "Test counter"}).Inc()
+
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	expect.That(t,
+		is.EqualTo(w.Code, http.StatusOK),
+		is.EqualTo(strings.Contains(w.Body.String(), "\ntest_total 1\n"), true),
+		is.EqualTo(strings.Contains(w.Body.String(), "\ngo_goroutines "), true),
+	)
+}
@@ -0,0 +1,14 @@
+// Package metricstest provides helpers for testing metrics.
+package metricstest
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// SampleCount returns the number of observations recorded by the histogram o.
+func SampleCount(o prometheus.Observer) uint64 {
+	var m dto.Metric
+	o.(prometheus.Metric).Write(&m)
+	return m.GetHistogram().GetSampleCount()
+}
@@ -0,0 +1,86 @@
+// Package ratelimit implements a keyed token bucket rate limiter.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval defines how often buckets which have been refilled completely are removed.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter implements a token bucket rate limiter maintaining a separate bucket for each key. Each bucket
+// holds up to burst tokens and is refilled with rate tokens per second. A request consumes a single token.
+type Limiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	lock      sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// New creates a new Limiter allowing perMinute requests per minute and key with bursts of up to burst
+// requests. A burst < 1 is treated as 1.
+func New(perMinute, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &Limiter{
+		rate:    float64(perMinute) / 60,
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow consumes a token from key's bucket. It returns true if a token has been available. Otherwise it
+// returns false and the duration after which the next token becomes available.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	} else {
+		b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	if l.rate <= 0 {
+		return false, time.Duration(1<<63 - 1)
+	}
+
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep removes all buckets that have been refilled completely as they are equal to a new bucket.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	l := New(60, 2)
+	l.now = func() time.Time { return now }
+
+	ok, _ := l.Allow("a")
+	expect.That(t, is.EqualTo(ok, true))
+
+	ok, _ = l.Allow("a")
+	expect.That(t, is.EqualTo(ok, true))
+
+	ok, retryAfter := l.Allow("a")
+	expect.That(t,
+		is.EqualTo(ok, false),
+		is.EqualTo(retryAfter, time.Second),
+	)
+
+	ok, _ = l.Allow("b")
+	expect.That(t, is.EqualTo(ok, true))
+
+	now = now.Add(time.Second)
+
+	ok, _ = l.Allow("a")
+	expect.That(t, is.EqualTo(ok, true))
+
+	ok, _ = l.Allow("a")
+	expect.That(t, is.EqualTo(ok, false))
+}
+
+func TestLimiter_sweep(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	l := New(60, 2)
+	l.now = func() time.Time { return now }
+
+	l.Allow("a")
+	l.Allow("b")
+	l.Allow("b")
+	l.Allow("b")
+
+	now = now.Add(sweepInterval)
+	l.Allow("c")
+
+	expect.That(t, is.EqualTo(len(l.buckets), 1))
+}
@@ -0,0 +1,15 @@
+package ingress
+
+import (
+	"net/http"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/metrics"
+)
+
+// ProvideAdmin creates the handler served on the admin listener. It exposes the application's metrics at
+// /metrics and must not be reachable from the public network as it performs no authentication.
+func ProvideAdmin() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	return mux
+}
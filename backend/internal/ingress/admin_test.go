@@ -0,0 +1,22 @@
+package ingress
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestProvideAdmin(t *testing.T) {
+	h := ProvideAdmin()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	expect.That(t, is.EqualTo(w.Code, http.StatusOK))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/sessions/", nil))
+	expect.That(t, is.EqualTo(w.Code, http.StatusNotFound))
+}
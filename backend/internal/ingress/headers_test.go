@@ -0,0 +1,30 @@
+package ingress
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	h := securityHeaders(true, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	expect.That(t,
+		is.EqualTo(w.Header().Get("Content-Security-Policy"), contentSecurityPolicy),
+		is.EqualTo(w.Header().Get("X-Content-Type-Options"), "nosniff"),
+		is.EqualTo(w.Header().Get("Referrer-Policy"), "strict-origin-when-cross-origin"),
+		is.EqualTo(w.Header().Get("Strict-Transport-Security"), ""),
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.TLS = &tls.ConnectionState{}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	expect.That(t, is.EqualTo(w.Header().Get("Strict-Transport-Security"), hstsMaxAge))
+}
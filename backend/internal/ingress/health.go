@@ -0,0 +1,54 @@
+package ingress
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/health"
+	"github.com/halimath/httputils/response"
+)
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+type checkResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type healthReport struct {
+	Status string        `json:"status"`
+	Checks []checkResult `json:"checks"`
+}
+
+// probeHandler creates a handler performing probe and responding with a JSON breakdown of all checks. The
+// response's status code is 503 if any check fails.
+func probeHandler(probe func(context.Context) health.Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := probe(r.Context())
+
+		res := healthReport{
+			Status: statusOK,
+			Checks: make([]checkResult, len(report.Results)),
+		}
+
+		for i, c := range report.Results {
+			res.Checks[i] = checkResult{Name: c.Name, Status: statusOK}
+			if c.Err != nil {
+				res.Checks[i].Status = statusFail
+				res.Checks[i].Error = c.Err.Error()
+			}
+		}
+
+		opts := []response.Option{response.AddHeader("Cache-Control", "no-store")}
+		if !report.OK() {
+			res.Status = statusFail
+			opts = append(opts, response.StatusCode(http.StatusServiceUnavailable))
+		}
+
+		response.JSON(w, r, res, opts...)
+	})
+}
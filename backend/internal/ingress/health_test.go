@@ -0,0 +1,43 @@
+package ingress
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/health"
+)
+
+func TestProbeHandler(t *testing.T) {
+	var probes health.Registry
+	probes.AddLivenessCheck("janitor", func(context.Context) error { return nil })
+	probes.AddReadinessCheck("repository", func(context.Context) error { return errors.New("unreachable") })
+
+	w := httptest.NewRecorder()
+	probeHandler(probes.Liveness).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	expect.That(t,
+		is.EqualTo(w.Code, http.StatusOK),
+		is.EqualTo(w.Header().Get("Cache-Control"), "no-store"),
+	)
+
+	w = httptest.NewRecorder()
+	probeHandler(probes.Readiness).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var got healthReport
+	err := json.Unmarshal(w.Body.Bytes(), &got)
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(w.Code, http.StatusServiceUnavailable),
+		is.DeepEqualTo(got, healthReport{
+			Status: statusFail,
+			Checks: []checkResult{
+				{Name: "draining", Status: statusOK},
+				{Name: "repository", Status: statusFail, Error: "unreachable"},
+			},
+		}),
+	)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
 	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
 	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/health"
 	"github.com/halimath/fate-core-remote-table/backend/internal/ingress/rest"
 	"github.com/halimath/fate-core-remote-table/backend/internal/ingress/web"
 	"github.com/halimath/httputils/response"
@@ -13,22 +14,48 @@ import (
 )
 
 func Provide(cfg config.Config, logger kvlog.Logger, version, commit string,
+	probes *health.Registry,
 	tokenHandler auth.TokenHandler,
 	createSession usecase.CreateSession,
+	listSessions usecase.ListSessions,
 	loadSession usecase.LoadSession,
+	updateSession usecase.UpdateSession,
+	archiveSession usecase.ArchiveSession,
+	deleteSession usecase.DeleteSession,
+	undoSession usecase.UndoSession,
+	redoSession usecase.RedoSession,
+	listSessionEvents usecase.ListSessionEvents,
+	loadSessionAt usecase.LoadSessionAt,
 	joinSession usecase.JoinSession,
+	spectateSession usecase.SpectateSession,
+	createInvite usecase.CreateInvite,
+	listInvites usecase.ListInvites,
+	revokeInvite usecase.RevokeInvite,
+	kickPlayer usecase.KickPlayer,
+	listBans usecase.ListBans,
+	liftBan usecase.LiftBan,
+	setMemberRole usecase.SetMemberRole,
 	createAspect usecase.CreateAspect,
 	createCharacterAspect usecase.CreateCharacterAspect,
 	deleteAspect usecase.DeleteAspect,
+	setAspectVisibility usecase.SetAspectVisibility,
+	setCharacterVisibility usecase.SetCharacterVisibility,
 	updateFatePoints usecase.UpdateFatePoints,
-) http.Handler {
+) (http.Handler, error) {
 	if cfg.DevMode {
 		response.DevMode = true
 	}
 
+	api, err := rest.Provide(cfg, logger, version, commit, tokenHandler, createSession, listSessions, loadSession, updateSession, archiveSession, deleteSession, undoSession, redoSession, listSessionEvents, loadSessionAt, joinSession, spectateSession, createInvite, listInvites, revokeInvite, kickPlayer, listBans, liftBan, setMemberRole, createAspect, createCharacterAspect, deleteAspect, setAspectVisibility, setCharacterVisibility, updateFatePoints)
+	if err != nil {
+		return nil, err
+	}
+
 	mux := http.NewServeMux()
-	mux.Handle("/api/", rest.Provide(cfg, logger, version, commit, tokenHandler, createSession, loadSession, joinSession, createAspect, createCharacterAspect, deleteAspect, updateFatePoints))
+	mux.Handle("GET /healthz", probeHandler(probes.Liveness))
+	mux.Handle("GET /readyz", probeHandler(probes.Readiness))
+	mux.Handle("/api/", api)
 	mux.Handle("/", web.Provide())
 
-	return kvlog.Middleware(logger, true)(mux)
+	return kvlog.Middleware(logger, true)(securityHeaders(cfg.TLSEnabled(), mux)), nil
 }
@@ -0,0 +1,28 @@
+package ingress
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+)
+
+// RedirectToHTTPS creates a handler redirecting all requests to the same URL using HTTPS on httpsPort. The
+// redirect is permanent and preserves the request method.
+func RedirectToHTTPS(httpsPort int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		if httpsPort != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(httpsPort))
+		}
+
+		target := *r.URL
+		target.Scheme = "https"
+		target.Host = host
+
+		http.Redirect(w, r, target.String(), http.StatusPermanentRedirect)
+	})
+}
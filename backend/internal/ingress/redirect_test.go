@@ -0,0 +1,35 @@
+package ingress
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestRedirectToHTTPS(t *testing.T) {
+	tests := []struct {
+		port         int
+		host, target string
+	}{
+		{443, "example.com", "https://example.com/api/sessions?x=1"},
+		{443, "example.com:80", "https://example.com/api/sessions?x=1"},
+		{8443, "example.com:8080", "https://example.com:8443/api/sessions?x=1"},
+		{8443, "[::1]:8080", "https://[::1]:8443/api/sessions?x=1"},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/api/sessions?x=1", nil)
+		r.Host = tt.host
+		w := httptest.NewRecorder()
+
+		RedirectToHTTPS(tt.port).ServeHTTP(w, r)
+
+		expect.That(t,
+			is.EqualTo(w.Code, http.StatusPermanentRedirect),
+			is.EqualTo(w.Header().Get("Location"), tt.target),
+		)
+	}
+}
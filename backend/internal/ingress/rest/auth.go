@@ -6,13 +6,14 @@ import (
 	"strings"
 
 	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
-	"github.com/halimath/httputils/errmux"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/tracing"
 	"github.com/halimath/httputils/response"
 	"github.com/halimath/kvlog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func newAuthMux(m auth.TokenHandler) http.Handler {
-	mux := errmux.NewServeMux()
+	mux := newRouteMux()
 
 	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) error {
 		logger := kvlog.FromContext(r.Context())
@@ -94,6 +95,7 @@ func authMiddleware(m auth.TokenHandler) func(http.Handler) http.Handler {
 				return
 			}
 
+			trace.SpanFromContext(r.Context()).SetAttributes(tracing.UserID.String(authInfo.UserID))
 			r = r.WithContext(auth.WithUserID(r.Context(), authInfo.UserID))
 
 			next.ServeHTTP(w, r)
@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// corsMaxAge defines how long, in seconds, browsers may cache the result of a preflight request.
+const corsMaxAge = "600"
+
+var (
+	corsAllowedMethods = strings.Join([]string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+	}, ", ")
+	corsAllowedHeaders = strings.Join([]string{
+		"Authorization", "Content-Type", "Idempotency-Key", "If-Match", "If-None-Match", "If-Modified-Since",
+	}, ", ")
+	corsExposedHeaders = strings.Join([]string{
+		"ETag", "Last-Modified", "Location", "Retry-After", "Idempotent-Replayed",
+	}, ", ")
+)
+
+// corsMiddleware allows browsers to call the API from the given origins. Requests from other origins are
+// passed on without CORS headers so browsers refuse to expose the responses; preflight requests from other
+// origins are rejected. The API authenticates using bearer tokens, so credentials are never allowed.
+func corsMiddleware(origins []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if len(origin) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+			allowed := slices.Contains(origins, origin)
+
+			if r.Method == http.MethodOptions && len(r.Header.Get("Access-Control-Request-Method")) > 0 {
+				if !allowed {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Allow-Methods", corsAllowedMethods)
+				w.Header().Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+				w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Expose-Headers", corsExposedHeaders)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
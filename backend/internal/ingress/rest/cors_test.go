@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	var called bool
+	h := corsMiddleware([]string{"https://tools.example.com"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	type test struct {
+		method, origin, requestMethod string
+		wantStatus                    int
+		wantCalled                    bool
+		wantAllowOrigin               string
+	}
+
+	tests := []test{
+		{http.MethodGet, "", "", http.StatusOK, true, ""},
+		{http.MethodGet, "https://tools.example.com", "", http.StatusOK, true, "https://tools.example.com"},
+		{http.MethodGet, "https://evil.example.com", "", http.StatusOK, true, ""},
+		{http.MethodOptions, "https://tools.example.com", http.MethodPut, http.StatusNoContent, false, "https://tools.example.com"},
+		{http.MethodOptions, "https://evil.example.com", http.MethodPut, http.StatusForbidden, false, ""},
+	}
+
+	for _, test := range tests {
+		called = false
+
+		r := httptest.NewRequest(test.method, "/api/sessions/", nil)
+		if len(test.origin) > 0 {
+			r.Header.Set("Origin", test.origin)
+		}
+		if len(test.requestMethod) > 0 {
+			r.Header.Set("Access-Control-Request-Method", test.requestMethod)
+		}
+		w := httptest.NewRecorder()
+
+		h.ServeHTTP(w, r)
+
+		expect.WithMessage(t, "%s from %q", test.method, test.origin).That(
+			is.EqualTo(w.Code, test.wantStatus),
+			is.EqualTo(called, test.wantCalled),
+			is.EqualTo(w.Header().Get("Access-Control-Allow-Origin"), test.wantAllowOrigin),
+		)
+	}
+}
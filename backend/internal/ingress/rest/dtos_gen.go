@@ -23,13 +23,41 @@ const (
 	CreateCharacterTypePC  CreateCharacterType = "PC"
 )
 
+// Defines values for InviteRole.
+const (
+	InviteRolePlayer    InviteRole = "Player"
+	InviteRoleSpectator InviteRole = "Spectator"
+)
+
+// Defines values for MemberRole.
+const (
+	MemberRoleCoGM      MemberRole = "CoGM"
+	MemberRoleOwner     MemberRole = "Owner"
+	MemberRolePlayer    MemberRole = "Player"
+	MemberRoleSpectator MemberRole = "Spectator"
+)
+
+// Defines values for SetMemberRoleRole.
+const (
+	SetMemberRoleRoleCoGM   SetMemberRoleRole = "CoGM"
+	SetMemberRoleRolePlayer SetMemberRoleRole = "Player"
+)
+
+// Defines values for VisibilityLevel.
+const (
+	VisibilityLevelGMOnly             VisibilityLevel = "GMOnly"
+	VisibilityLevelPublic             VisibilityLevel = "Public"
+	VisibilityLevelSelectedCharacters VisibilityLevel = "SelectedCharacters"
+)
+
 // Aspect defines model for Aspect.
 type Aspect struct {
 	// Id The unique id of the aspect
 	Id string `json:"id"`
 
 	// Name The aspect's name
-	Name string `json:"name"`
+	Name       string     `json:"name"`
+	Visibility Visibility `json:"visibility"`
 }
 
 // AuthenticationInfo Information about the current user
@@ -41,6 +69,15 @@ type AuthenticationInfo struct {
 	UserId string `json:"userId"`
 }
 
+// Ban defines model for Ban.
+type Ban struct {
+	// Created Date the ban has been issued
+	Created time.Time `json:"created"`
+
+	// UserId The banned user's id
+	UserId string `json:"userId"`
+}
+
 // Character defines model for Character.
 type Character struct {
 	Aspects []Aspect `json:"aspects"`
@@ -55,8 +92,9 @@ type Character struct {
 	Name string `json:"name"`
 
 	// OwnerId The unique id of the characters's owner
-	OwnerId string        `json:"ownerId"`
-	Type    CharacterType `json:"type"`
+	OwnerId    string        `json:"ownerId"`
+	Type       CharacterType `json:"type"`
+	Visibility Visibility    `json:"visibility"`
 }
 
 // CharacterType defines model for Character.Type.
@@ -65,7 +103,8 @@ type CharacterType string
 // CreateAspect defines model for CreateAspect.
 type CreateAspect struct {
 	// Name The aspect's name
-	Name string `json:"name"`
+	Name       string      `json:"name"`
+	Visibility *Visibility `json:"visibility,omitempty"`
 }
 
 // CreateCharacter defines model for CreateCharacter.
@@ -78,18 +117,78 @@ type CreateCharacter struct {
 // CreateCharacterType defines model for CreateCharacter.Type.
 type CreateCharacterType string
 
+// CreateInvite defines model for CreateInvite.
+type CreateInvite struct {
+	// ExpiresIn Number of seconds the invite is valid for. If omitted, the invite never expires.
+	ExpiresIn *int `json:"expiresIn,omitempty"`
+
+	// MaxUses Maximum number of joins using this invite. If omitted, the invite can be used any number of times.
+	MaxUses *int `json:"maxUses,omitempty"`
+
+	// Role The role of users using the invite
+	Role *InviteRole `json:"role,omitempty"`
+}
+
 // CreateSession defines model for CreateSession.
 type CreateSession struct {
 	// Title Human readable title of the session
 	Title string `json:"title"`
 }
 
+// FieldError Describes the validation failure of a single request field
+type FieldError struct {
+	// Field The name of the invalid field
+	Field string `json:"field"`
+
+	// Message Human readable description of the validation failure
+	Message string `json:"message"`
+}
+
+// Invite defines model for Invite.
+type Invite struct {
+	// Created Creation date of the invite
+	Created time.Time `json:"created"`
+
+	// Expires Expiry date of the invite. Missing if the invite never expires.
+	Expires *time.Time `json:"expires,omitempty"`
+
+	// Id The unique invite code
+	Id string `json:"id"`
+
+	// MaxUses Maximum number of joins using this invite. Missing if the invite can be used any number of times.
+	MaxUses *int `json:"maxUses,omitempty"`
+
+	// Role The role of users using the invite
+	Role InviteRole `json:"role"`
+
+	// Uses Number of joins that used this invite
+	Uses int `json:"uses"`
+}
+
+// InviteRole The role of users using the invite
+type InviteRole string
+
 // JoinSession defines model for JoinSession.
 type JoinSession struct {
+	// Invite The invite code handed out by the game master. Required unless the user is already a member of the session.
+	Invite *string `json:"invite,omitempty"`
+
 	// Name Name of the character joining the session
 	Name string `json:"name"`
 }
 
+// Member defines model for Member.
+type Member struct {
+	// Role The member's role in the session
+	Role MemberRole `json:"role"`
+
+	// UserId The member's user id
+	UserId string `json:"userId"`
+}
+
+// MemberRole The member's role in the session
+type MemberRole string
+
 // ProblemDetails A problem details representation as defined in [RFC9457](https://www.rfc-editor.org/rfc/rfc9457)
 type ProblemDetails struct {
 	// Detail Additional details description
@@ -113,11 +212,14 @@ type ProblemDetails struct {
 
 // Session defines model for Session.
 type Session struct {
+	// Archived Whether the session has been archived. Archived sessions are read-only.
+	Archived   bool        `json:"archived"`
 	Aspects    []Aspect    `json:"aspects"`
 	Characters []Character `json:"characters"`
 
 	// Id The unique id of the session
-	Id string `json:"id"`
+	Id      string   `json:"id"`
+	Members []Member `json:"members"`
 
 	// OwnerId The unique id of the session's owner
 	OwnerId string `json:"ownerId"`
@@ -126,12 +228,87 @@ type Session struct {
 	Title string `json:"title"`
 }
 
+// SessionEvent A single change recorded in a session's history
+type SessionEvent struct {
+	// ActorId The id of the user who made the change; missing for changes not made by a user
+	ActorId *string `json:"actorId,omitempty"`
+
+	// Data The event's type specific payload
+	Data map[string]interface{} `json:"data"`
+
+	// Time Date the change has been made
+	Time time.Time `json:"time"`
+
+	// Type The event's type, e.g. `FatePointsChanged`
+	Type string `json:"type"`
+
+	// Version The session version produced by the change. Events recorded by the same change share it.
+	Version int64 `json:"version"`
+}
+
+// SessionSummary defines model for SessionSummary.
+type SessionSummary struct {
+	// Archived Whether the session has been archived. Archived sessions are read-only.
+	Archived bool `json:"archived"`
+
+	// Id The unique id of the session
+	Id string `json:"id"`
+
+	// LastModified Date of the session's last modification
+	LastModified time.Time `json:"lastModified"`
+
+	// Title Human readable title of the session
+	Title string `json:"title"`
+}
+
+// SetMemberRole defines model for SetMemberRole.
+type SetMemberRole struct {
+	// Role The role to assign to the member
+	Role SetMemberRoleRole `json:"role"`
+}
+
+// SetMemberRoleRole The role to assign to the member
+type SetMemberRoleRole string
+
+// SpectateSession defines model for SpectateSession.
+type SpectateSession struct {
+	// Invite The spectator invite code handed out by the game master
+	Invite string `json:"invite"`
+}
+
 // UpdateFatePoints defines model for UpdateFatePoints.
 type UpdateFatePoints struct {
 	// FatePointsDelta Number to modify character's Fate Points (negative or positive)
 	FatePointsDelta int `json:"fatePointsDelta"`
 }
 
+// UpdateSession defines model for UpdateSession.
+type UpdateSession struct {
+	// Title Human readable title of the session
+	Title string `json:"title"`
+}
+
+// ValidationProblemDetails A problem details representation listing the fields that failed validation
+type ValidationProblemDetails struct {
+	// Detail Additional details description
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors The invalid fields
+	Errors []FieldError `json:"errors"`
+
+	// Instance Identifier of the instance that caused this problem
+	Instance *string `json:"instance,omitempty"`
+
+	// Status Status code
+	Status *int `json:"status,omitempty"`
+
+	// Title Human readable title - must be given
+	Title string `json:"title"`
+
+	// Type Type discriminator
+	Type string `json:"type"`
+}
+
 // VersionInfo defines model for VersionInfo.
 type VersionInfo struct {
 	// ApiVersion The version string of the API specs.
@@ -144,17 +321,54 @@ type VersionInfo struct {
 	Version string `json:"version"`
 }
 
+// Visibility Defines who may see an aspect or a non-player character
+type Visibility struct {
+	// CharacterIds The characters whose owners may see the data if level is `SelectedCharacters`. Only returned to
+	// game masters.
+	CharacterIds *[]string `json:"characterIds,omitempty"`
+
+	// Level The visibility level
+	Level VisibilityLevel `json:"level"`
+}
+
+// VisibilityLevel The visibility level
+type VisibilityLevel string
+
+// ListSessionEventsParams defines parameters for ListSessionEvents.
+type ListSessionEventsParams struct {
+	// Since Only list events which produced a session version greater than this one
+	Since *int64 `form:"since,omitempty" json:"since,omitempty"`
+}
+
 // CreateSessionJSONRequestBody defines body for CreateSession for application/json ContentType.
 type CreateSessionJSONRequestBody = CreateSession
 
+// UpdateSessionJSONRequestBody defines body for UpdateSession for application/json ContentType.
+type UpdateSessionJSONRequestBody = UpdateSession
+
 // CreateAspectJSONRequestBody defines body for CreateAspect for application/json ContentType.
 type CreateAspectJSONRequestBody = CreateAspect
 
+// SetAspectVisibilityJSONRequestBody defines body for SetAspectVisibility for application/json ContentType.
+type SetAspectVisibilityJSONRequestBody = Visibility
+
 // CreateCharacterAspectJSONRequestBody defines body for CreateCharacterAspect for application/json ContentType.
 type CreateCharacterAspectJSONRequestBody = CreateAspect
 
 // UpdateFatePointsJSONRequestBody defines body for UpdateFatePoints for application/json ContentType.
 type UpdateFatePointsJSONRequestBody = UpdateFatePoints
 
+// SetCharacterVisibilityJSONRequestBody defines body for SetCharacterVisibility for application/json ContentType.
+type SetCharacterVisibilityJSONRequestBody = Visibility
+
+// CreateInviteJSONRequestBody defines body for CreateInvite for application/json ContentType.
+type CreateInviteJSONRequestBody = CreateInvite
+
 // JoinSessionJSONRequestBody defines body for JoinSession for application/json ContentType.
 type JoinSessionJSONRequestBody = JoinSession
+
+// SetMemberRoleJSONRequestBody defines body for SetMemberRole for application/json ContentType.
+type SetMemberRoleJSONRequestBody = SetMemberRole
+
+// SpectateSessionJSONRequestBody defines body for SpectateSession for application/json ContentType.
+type SpectateSessionJSONRequestBody = SpectateSession
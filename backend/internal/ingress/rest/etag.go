@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+	"github.com/halimath/httputils/response"
+)
+
+// formatETag formats the strong entity tag for a session's version.
+func formatETag(version uint64) string {
+	return `"` + strconv.FormatUint(version, 10) + `"`
+}
+
+// parseETags parses the list of entity tags contained in an If-Match or If-None-Match header. wildcard is
+// true if the header contains the special value "*".
+func parseETags(header string) (tags []string, wildcard bool) {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+
+		if len(tag) > 0 {
+			tags = append(tags, tag)
+		}
+	}
+
+	return
+}
+
+// matchesWeak implements the weak comparison of entity tags used with If-None-Match.
+func matchesWeak(tags []string, etag string) bool {
+	for _, tag := range tags {
+		if strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchMiddleware honors the If-Match header of requests modifying a session. The expected versions are
+// passed to the use cases which fail with usecase.ErrPreconditionFailed if the session has been modified in
+// the meantime.
+func ifMatchMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("If-Match")
+		if r.Method == http.MethodGet || r.Method == http.MethodHead || len(header) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		tags, wildcard := parseETags(header)
+		if wildcard {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		versions := make([]uint64, 0, len(tags))
+		for _, tag := range tags {
+			// If-Match uses the strong comparison so weak tags never match.
+			if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+				continue
+			}
+
+			v, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 64)
+			if err == nil {
+				versions = append(versions, v)
+			}
+		}
+
+		if len(versions) == 0 {
+			sendPreconditionFailed(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(usecase.WithExpectedVersions(r.Context(), versions...)))
+	})
+}
+
+// versionETagMiddleware sets the ETag header of successful responses to requests modifying a session to the
+// session's version after the modification so that clients can send further conditional requests without
+// reloading the session.
+func versionETagMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ew := &etagWriter{ResponseWriter: w}
+		next.ServeHTTP(ew, r.WithContext(usecase.WithVersionRecorder(r.Context(), &ew.version)))
+	})
+}
+
+// etagWriter sets the ETag header for version before writing a successful status code. A version of 0 means
+// that no version has been recorded as sessions start with version 1.
+type etagWriter struct {
+	http.ResponseWriter
+	version     uint64
+	wroteHeader bool
+}
+
+func (w *etagWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if w.version > 0 && status >= 200 && status < 300 {
+			w.Header().Set("ETag", formatETag(w.version))
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func sendPreconditionFailed(w http.ResponseWriter, r *http.Request) error {
+	return response.Problem(w, r, response.ProblemDetails{
+		Type:   "https://github.com/halimath/fate-table/problem/preconditionFailed",
+		Title:  "Precondition failed",
+		Status: http.StatusPreconditionFailed,
+		Detail: "The session has been modified since the version given in the If-Match header. Reload the session and retry.",
+	})
+}
@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+)
+
+func TestIfMatchMiddleware(t *testing.T) {
+	var got []uint64
+	var gotOK bool
+
+	h := ifMatchMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, gotOK = usecase.ExpectedVersions(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	type test struct {
+		method, ifMatch string
+		wantStatus      int
+		wantOK          bool
+		want            []uint64
+	}
+
+	tests := []test{
+		{http.MethodPut, "", http.StatusNoContent, false, nil},
+		{http.MethodPut, "*", http.StatusNoContent, false, nil},
+		{http.MethodGet, `"1"`, http.StatusNoContent, false, nil},
+		{http.MethodPut, `"1"`, http.StatusNoContent, true, []uint64{1}},
+		{http.MethodDelete, `"1", W/"2", "3"`, http.StatusNoContent, true, []uint64{1, 3}},
+		{http.MethodPost, `W/"1"`, http.StatusPreconditionFailed, false, nil},
+		{http.MethodPost, `"abc"`, http.StatusPreconditionFailed, false, nil},
+	}
+
+	for _, test := range tests {
+		got, gotOK = nil, false
+
+		r := httptest.NewRequest(test.method, "/1", nil)
+		if len(test.ifMatch) > 0 {
+			r.Header.Set("If-Match", test.ifMatch)
+		}
+		w := httptest.NewRecorder()
+
+		h.ServeHTTP(w, r)
+
+		expect.WithMessage(t, "%s %s", test.method, test.ifMatch).That(
+			is.EqualTo(w.Code, test.wantStatus),
+			is.EqualTo(gotOK, test.wantOK),
+			is.DeepEqualTo(got, test.want),
+		)
+	}
+}
+
+func TestVersionETagMiddleware(t *testing.T) {
+	type test struct {
+		method  string
+		version uint64
+		status  int
+		want    string
+	}
+
+	tests := []test{
+		{http.MethodPut, 3, http.StatusNoContent, `"3"`},
+		{http.MethodPost, 1, http.StatusCreated, `"1"`},
+		{http.MethodPut, 0, http.StatusNoContent, ""},
+		{http.MethodPut, 3, http.StatusPreconditionFailed, ""},
+		{http.MethodGet, 3, http.StatusOK, ""},
+	}
+
+	for _, test := range tests {
+		h := versionETagMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if test.version > 0 {
+				usecase.RecordVersion(r.Context(), test.version)
+			}
+			w.WriteHeader(test.status)
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(test.method, "/1", nil))
+
+		expect.WithMessage(t, "%s %d %d", test.method, test.version, test.status).That(
+			is.EqualTo(w.Code, test.status),
+			is.EqualTo(w.Header().Get("ETag"), test.want),
+		)
+	}
+}
+
+func TestMatchesWeak(t *testing.T) {
+	tags, wildcard := parseETags(`W/"1", "2"`)
+	expect.That(t,
+		is.EqualTo(wildcard, false),
+		is.EqualTo(matchesWeak(tags, formatETag(1)), true),
+		is.EqualTo(matchesWeak(tags, formatETag(2)), true),
+		is.EqualTo(matchesWeak(tags, formatETag(3)), false),
+	)
+}
@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/idempotency"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/metrics"
+	"github.com/halimath/httputils/response"
+	"github.com/halimath/kvlog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxIdempotencyKeyLength defines the maximum length of an Idempotency-Key header value.
+const maxIdempotencyKeyLength = 255
+
+var idempotentReplaysTotal = metrics.Default.NewCounter(prometheus.CounterOpts{
+	Name: "fate_table_idempotent_replays_total",
+	Help: "Number of responses replayed for retried requests carrying an idempotency key",
+})
+
+// newIdempotencyStore creates the store for idempotent responses. It returns nil if idempotency key support
+// is disabled.
+func newIdempotencyStore(cfg config.Config) *idempotency.Store {
+	if cfg.IdempotencyKeyTTL <= 0 {
+		return nil
+	}
+	return idempotency.New(cfg.IdempotencyKeyTTL)
+}
+
+// idempotencyMiddleware implements support for the Idempotency-Key header on mutating requests. The response
+// to the first request with a given key is recorded per user and replayed for any retry of the same request
+// instead of executing it again. Server errors are not recorded so that such requests may be retried.
+func idempotencyMiddleware(store *idempotency.Store) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idempotencyKey := r.Header.Get("Idempotency-Key")
+			if store == nil || len(idempotencyKey) == 0 || r.Method == http.MethodGet || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(idempotencyKey) > maxIdempotencyKeyLength {
+				response.Problem(w, r, response.ProblemDetails{
+					Type:   "https://github.com/halimath/fate-table/problem/invalidIdempotencyKey",
+					Title:  "Invalid idempotency key",
+					Status: http.StatusBadRequest,
+					Detail: "The Idempotency-Key header must not exceed 255 characters.",
+				})
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				response.Error(w, r, err)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			userID, _ := auth.UserID(r.Context())
+			key := userID + "\x00" + idempotencyKey
+			fingerprint := requestFingerprint(r, body)
+
+			state, recorded := store.Reserve(key, fingerprint)
+			switch state {
+			case idempotency.Completed:
+				idempotentReplaysTotal.Inc()
+				kvlog.FromContext(r.Context()).Logs("replaying idempotent response", kvlog.WithKV("key", idempotencyKey))
+				replayResponse(w, recorded)
+				return
+
+			case idempotency.InFlight:
+				response.Problem(w, r, response.ProblemDetails{
+					Type:   "https://github.com/halimath/fate-table/problem/idempotencyKeyInFlight",
+					Title:  "Request in progress",
+					Status: http.StatusConflict,
+					Detail: "A request with the same idempotency key is currently being processed.",
+				})
+				return
+
+			case idempotency.Mismatch:
+				response.Problem(w, r, response.ProblemDetails{
+					Type:   "https://github.com/halimath/fate-table/problem/idempotencyKeyReused",
+					Title:  "Idempotency key reused",
+					Status: http.StatusUnprocessableEntity,
+					Detail: "The idempotency key has already been used for a different request.",
+				})
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			completed := false
+			defer func() {
+				if !completed {
+					store.Release(key)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= 500 {
+				return
+			}
+
+			store.Complete(key, idempotency.Response{
+				Status: rec.status,
+				Header: rec.Header().Clone(),
+				Body:   bytes.Clone(rec.body.Bytes()),
+			})
+			completed = true
+		})
+	}
+}
+
+// requestFingerprint identifies a request by its method, path, If-Match header and body. The If-Match header
+// is included as it changes the request's outcome.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.Path)
+	h.Write([]byte{0})
+	io.WriteString(h, r.Header.Get("If-Match"))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replayResponse(w http.ResponseWriter, recorded *idempotency.Response) {
+	for name, values := range recorded.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(recorded.Status)
+	w.Write(recorded.Body)
+}
@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/idempotency"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	calls := 0
+	h := idempotencyMiddleware(idempotency.New(time.Hour))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Location", "/1/aspects/"+string(body))
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, string(body))
+	}))
+
+	send := func(userID, method, key, body string, header ...string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/1/aspects", strings.NewReader(body))
+		r = r.WithContext(auth.WithUserID(r.Context(), userID))
+		if len(key) > 0 {
+			r.Header.Set("Idempotency-Key", key)
+		}
+		for i := 0; i < len(header); i += 2 {
+			r.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := send("1", http.MethodPost, "k", "a")
+	expect.That(t,
+		is.EqualTo(w.Code, http.StatusCreated),
+		is.EqualTo(calls, 1),
+	)
+
+	w = send("1", http.MethodPost, "k", "a")
+	expect.That(t,
+		is.EqualTo(w.Code, http.StatusCreated),
+		is.EqualTo(w.Body.String(), "a"),
+		is.EqualTo(w.Header().Get("Location"), "/1/aspects/a"),
+		is.EqualTo(w.Header().Get("Idempotent-Replayed"), "true"),
+		is.EqualTo(calls, 1),
+	)
+
+	w = send("1", http.MethodPost, "k", "b")
+	expect.That(t,
+		is.EqualTo(w.Code, http.StatusUnprocessableEntity),
+		is.EqualTo(calls, 1),
+	)
+
+	w = send("1", http.MethodPost, "k", "a", "If-Match", `"1"`)
+	expect.That(t,
+		is.EqualTo(w.Code, http.StatusUnprocessableEntity),
+		is.EqualTo(calls, 1),
+	)
+
+	w = send("2", http.MethodPost, "k", "a")
+	expect.That(t,
+		is.EqualTo(w.Code, http.StatusCreated),
+		is.EqualTo(calls, 2),
+	)
+
+	send("1", http.MethodPost, "", "a")
+	send("1", http.MethodGet, "k", "")
+	expect.That(t, is.EqualTo(calls, 4))
+}
+
+func TestIdempotencyMiddleware_serverError(t *testing.T) {
+	calls := 0
+	h := idempotencyMiddleware(idempotency.New(time.Hour))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	for range 2 {
+		r := httptest.NewRequest(http.MethodPost, "/1/join", nil)
+		r.Header.Set("Idempotency-Key", "k")
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	expect.That(t, is.EqualTo(calls, 2))
+}
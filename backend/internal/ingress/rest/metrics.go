@@ -0,0 +1,204 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/metrics"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/tracing"
+	"github.com/halimath/httputils/errmux"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// presenceWindow defines how long a user is considered connected to a session after the last request.
+const presenceWindow = time.Minute
+
+var (
+	requestsTotal = metrics.Default.NewCounterVec(prometheus.CounterOpts{
+		Name: "fate_table_http_requests_total",
+		Help: "Number of API requests by route, method and status code",
+	}, []string{"route", "method", "status"})
+	requestDuration = metrics.Default.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fate_table_http_request_duration_seconds",
+		Help:    "Duration of API requests by route and method",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	activity = newPresence(presenceWindow)
+)
+
+func init() {
+	metrics.Default.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "fate_table_active_sessions",
+		Help: "Number of sessions accessed within the last minute",
+	}, func() float64 {
+		sessions, _ := activity.counts()
+		return float64(sessions)
+	})
+	metrics.Default.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "fate_table_connected_players",
+		Help: "Number of users who accessed a session within the last minute",
+	}, func() float64 {
+		_, users := activity.counts()
+		return float64(users)
+	})
+}
+
+type routeContextKeyType string
+
+const routeContextKey routeContextKeyType = "route"
+
+// route collects the route pattern matched by a request. prefix is set by mountedAt and pattern by
+// routeMux.
+type route struct {
+	prefix, pattern string
+}
+
+func (r *route) String() string {
+	if len(r.pattern) == 0 {
+		return "unmatched"
+	}
+
+	path := r.pattern
+	if _, p, ok := strings.Cut(path, " "); ok {
+		path = p
+	}
+
+	return r.prefix + strings.TrimSuffix(path, "{$}")
+}
+
+// requestMetricsMiddleware records the number and duration of requests per route.
+func requestMetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rt := &route{}
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), routeContextKey, rt)))
+
+		name := rt.String()
+		requestsTotal.WithLabelValues(name, r.Method, strconv.Itoa(rec.status)).Inc()
+		requestDuration.WithLabelValues(name, r.Method).Observe(time.Since(start).Seconds())
+	})
+}
+
+// mountedAt records prefix as the path prefix of routes handled by next.
+func mountedAt(prefix string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rt, ok := r.Context().Value(routeContextKey).(*route); ok {
+			rt.prefix = prefix
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routed records pattern as the route matched by requests handled by next.
+func routed(pattern string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rt, ok := r.Context().Value(routeContextKey).(*route); ok {
+			rt.pattern = pattern
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routeMux wraps an errmux.ServeMux recording the pattern of the matched route for request metrics and the
+// requested session's id for tracing.
+type routeMux struct {
+	*errmux.ServeMux
+}
+
+func newRouteMux() routeMux {
+	return routeMux{errmux.NewServeMux()}
+}
+
+func (m routeMux) Handle(pattern string, h errmux.Handler) {
+	m.ServeMux.Handle(pattern, errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		if rt, ok := r.Context().Value(routeContextKey).(*route); ok {
+			rt.pattern = pattern
+		}
+		if id := r.PathValue("id"); len(id) > 0 {
+			trace.SpanFromContext(r.Context()).SetAttributes(tracing.SessionID.String(id))
+		}
+		return h.ServeHTTP(w, r)
+	}))
+}
+
+func (m routeMux) HandleFunc(pattern string, h func(http.ResponseWriter, *http.Request) error) {
+	m.Handle(pattern, errmux.HandlerFunc(h))
+}
+
+// statusRecorder captures the status code written to the wrapped http.ResponseWriter.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// presence tracks which users accessed which sessions within a sliding window.
+type presence struct {
+	window time.Duration
+	now    func() time.Time
+
+	lock      sync.Mutex
+	seen      map[[2]string]time.Time
+	lastSweep time.Time
+}
+
+func newPresence(window time.Duration) *presence {
+	return &presence{
+		window: window,
+		now:    time.Now,
+		seen:   make(map[[2]string]time.Time),
+	}
+}
+
+// observe records that userID accessed sessionID.
+func (p *presence) observe(sessionID, userID string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.now()
+	p.seen[[2]string{sessionID, userID}] = now
+
+	if now.Sub(p.lastSweep) >= p.window {
+		p.lastSweep = now
+		p.sweep(now.Add(-p.window))
+	}
+}
+
+// sweep forgets all entries seen before deadline. The caller must hold p's lock.
+func (p *presence) sweep(deadline time.Time) {
+	for key, t := range p.seen {
+		if t.Before(deadline) {
+			delete(p.seen, key)
+		}
+	}
+}
+
+// counts returns the number of distinct sessions and users seen within the window and forgets older entries.
+func (p *presence) counts() (sessions, users int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.sweep(p.now().Add(-p.window))
+
+	sessionIDs := make(map[string]struct{})
+	userIDs := make(map[string]struct{})
+
+	for key := range p.seen {
+		sessionIDs[key[0]] = struct{}{}
+		userIDs[key[1]] = struct{}{}
+	}
+
+	return len(sessionIDs), len(userIDs)
+}
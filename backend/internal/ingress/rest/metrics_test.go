@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/metrics/metricstest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRequestMetricsMiddleware(t *testing.T) {
+	sessions := newRouteMux()
+	sessions.HandleFunc("GET /{id}/bans", func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/test/sessions/", mountedAt("/test/sessions", http.StripPrefix("/test/sessions", sessions)))
+	h := requestMetricsMiddleware(mux)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test/sessions/1/bans", nil))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test/sessions/2/bans", nil))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test/unknown", nil))
+
+	expect.That(t,
+		is.EqualTo(testutil.ToFloat64(requestsTotal.WithLabelValues("/test/sessions/{id}/bans", http.MethodGet, "204")), 2.0),
+		is.EqualTo(metricstest.SampleCount(requestDuration.WithLabelValues("/test/sessions/{id}/bans", http.MethodGet)), uint64(2)),
+		is.EqualTo(testutil.ToFloat64(requestsTotal.WithLabelValues("unmatched", http.MethodGet, "404")), 1.0),
+	)
+}
+
+func TestPresence(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	p := newPresence(time.Minute)
+	p.now = func() time.Time { return now }
+
+	p.observe("1", "a")
+	p.observe("1", "b")
+	p.observe("2", "a")
+
+	sessions, users := p.counts()
+	expect.That(t,
+		is.EqualTo(sessions, 2),
+		is.EqualTo(users, 2),
+	)
+
+	now = now.Add(30 * time.Second)
+	p.observe("1", "a")
+	now = now.Add(31 * time.Second)
+
+	sessions, users = p.counts()
+	expect.That(t,
+		is.EqualTo(sessions, 1),
+		is.EqualTo(users, 1),
+	)
+}
@@ -0,0 +1,230 @@
+package rest
+
+//go:generate cp ../../../../docs/api.yaml api.yaml
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/halimath/httputils/response"
+	"github.com/halimath/kvlog"
+)
+
+// apiSpec contains the OpenAPI document describing the REST API. It is a copy of docs/api.yaml kept in
+// sync via go generate.
+//
+//go:embed api.yaml
+var apiSpec []byte
+
+func init() {
+	// Don't include the failing schema in error messages as these are sent to clients.
+	openapi3.SchemaErrorDetailsDisabled = true
+}
+
+// specValidator validates requests and optionally responses against the OpenAPI document.
+type specValidator struct {
+	router            routers.Router
+	validateResponses bool
+	options           openapi3filter.Options
+}
+
+// newSpecValidator loads the embedded OpenAPI document and creates a specValidator from it.
+func newSpecValidator(validateResponses bool) (*specValidator, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(apiSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load api spec: %w", err)
+	}
+
+	// Route requests regardless of the host the server is reached at.
+	doc.Servers = openapi3.Servers{{URL: "/api"}}
+
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid api spec: %w", err)
+	}
+
+	rejectUnknownProperties(doc)
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api spec router: %w", err)
+	}
+
+	return &specValidator{
+		router:            router,
+		validateResponses: validateResponses,
+		options: openapi3filter.Options{
+			MultiError:          true,
+			SkipSettingDefaults: true,
+			// Authentication is handled by authMiddleware.
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	}, nil
+}
+
+// rejectUnknownProperties replaces all request body schemas of doc with copies that forbid properties not
+// declared in the schema. The shared component schemas are left untouched as they are also used to compose
+// response schemas.
+func rejectUnknownProperties(doc *openapi3.T) {
+	for _, path := range doc.Paths.Map() {
+		for _, op := range path.Operations() {
+			if op.RequestBody == nil || op.RequestBody.Value == nil {
+				continue
+			}
+
+			for _, mediaType := range op.RequestBody.Value.Content {
+				mediaType.Schema = strictSchema(mediaType.Schema)
+			}
+		}
+	}
+}
+
+func strictSchema(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if ref == nil || ref.Value == nil {
+		return ref
+	}
+
+	s := *ref.Value
+
+	if s.Items != nil {
+		s.Items = strictSchema(s.Items)
+	}
+
+	if s.Type.Is(openapi3.TypeObject) && s.AdditionalProperties.Schema == nil {
+		properties := make(openapi3.Schemas, len(s.Properties))
+		for name, p := range s.Properties {
+			properties[name] = strictSchema(p)
+		}
+		s.Properties = properties
+
+		forbidden := false
+		s.AdditionalProperties = openapi3.AdditionalProperties{Has: &forbidden}
+	}
+
+	return &openapi3.SchemaRef{Value: &s}
+}
+
+// middleware validates requests before passing them to next. Invalid requests are rejected with a 400
+// problem response. Requests not described by the OpenAPI document are passed to next unchanged.
+func (v *specValidator) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := v.router.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &v.options,
+		}
+
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			sendInvalidRequest(w, r, err)
+			return
+		}
+
+		if !v.validateResponses {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 rec.status,
+			Header:                 rec.Header(),
+			Body:                   io.NopCloser(&rec.body),
+			Options:                &v.options,
+		})
+		if err != nil {
+			kvlog.FromContext(r.Context()).Logs("response violates api spec",
+				kvlog.WithKV("method", r.Method), kvlog.WithKV("path", r.URL.Path), kvlog.WithErr(err))
+		}
+	})
+}
+
+func sendInvalidRequest(w http.ResponseWriter, r *http.Request, err error) error {
+	var errs openapi3.MultiError
+	if !errors.As(err, &errs) {
+		errs = openapi3.MultiError{err}
+	}
+
+	fieldErrors := make([]any, 0, len(errs))
+	for _, e := range errs {
+		fieldErrors = append(fieldErrors, convertSpecError(e))
+	}
+
+	return response.Problem(w, r, response.ProblemDetails{
+		Type:   "https://github.com/halimath/fate-table/problem/invalidRequest",
+		Title:  "Invalid request",
+		Status: http.StatusBadRequest,
+		Detail: "The request does not conform to the API specification.",
+		Errors: fieldErrors,
+	})
+}
+
+func convertSpecError(err error) FieldError {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return FieldError{Message: err.Error()}
+	}
+
+	field := "body"
+	if reqErr.Parameter != nil {
+		field = reqErr.Parameter.Name
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(reqErr.Err, &schemaErr) {
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 && reqErr.Parameter == nil {
+			field = strings.Join(pointer, ".")
+		}
+		return FieldError{Field: field, Message: schemaErr.Reason}
+	}
+
+	var multiErr openapi3.MultiError
+	if errors.As(reqErr.Err, &multiErr) && len(multiErr) > 0 {
+		return FieldError{Field: field, Message: multiErr.Error()}
+	}
+
+	message := reqErr.Reason
+	if reqErr.Err != nil {
+		if len(message) > 0 {
+			message += ": "
+		}
+		message += reqErr.Err.Error()
+	}
+
+	return FieldError{Field: field, Message: message}
+}
+
+// responseRecorder captures the status code and body written to the wrapped http.ResponseWriter.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
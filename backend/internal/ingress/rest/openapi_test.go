@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+func TestAPISpecInSync(t *testing.T) {
+	want, err := os.ReadFile("../../../../docs/api.yaml")
+	if err != nil {
+		t.Skipf("api docs not available: %v", err)
+	}
+
+	expect.WithMessage(t, "api.yaml is out of sync with docs/api.yaml; run go generate").That(
+		is.EqualTo(string(apiSpec), string(want)),
+	)
+}
+
+func TestSpecValidator(t *testing.T) {
+	v, err := newSpecValidator(true)
+	expect.That(t, is.NoError(err))
+
+	called := false
+	h := v.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("1"))
+	}))
+
+	type test struct {
+		label, method, path, body string
+		wantStatus                int
+		wantCalled                bool
+	}
+
+	tests := []test{
+		{"valid", http.MethodPost, "/api/sessions/", `{"title": "Test"}`, http.StatusCreated, true},
+		{"unknown_property", http.MethodPost, "/api/sessions/", `{"title": "Test", "owner": "1"}`, http.StatusBadRequest, false},
+		{"missing_property", http.MethodPost, "/api/sessions/", `{}`, http.StatusBadRequest, false},
+		{"invalid_type", http.MethodPost, "/api/sessions/", `{"title": 1}`, http.StatusBadRequest, false},
+		{"unknown_nested_property", http.MethodPost, "/api/sessions/1/aspects", `{"name": "Test", "visibility": {"level": "Public", "foo": 1}}`, http.StatusBadRequest, false},
+		{"invalid_enum", http.MethodPut, "/api/sessions/1/members/2/role", `{"role": "Owner"}`, http.StatusBadRequest, false},
+		{"not_in_spec", http.MethodGet, "/api/unknown", "", http.StatusCreated, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.label, func(t *testing.T) {
+			called = false
+
+			r := httptest.NewRequest(test.method, test.path, bytes.NewReader([]byte(test.body)))
+			if len(test.body) > 0 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			expect.That(t,
+				is.EqualTo(w.Code, test.wantStatus),
+				is.EqualTo(called, test.wantCalled),
+			)
+
+			if w.Code == http.StatusBadRequest {
+				var problem struct {
+					Errors []FieldError `json:"errors"`
+				}
+				expect.That(t,
+					is.NoError(json.Unmarshal(w.Body.Bytes(), &problem)),
+					is.EqualTo(len(problem.Errors) > 0, true),
+				)
+			}
+		})
+	}
+}
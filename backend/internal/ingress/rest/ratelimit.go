@@ -0,0 +1,140 @@
+package rest
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/metrics"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/ratelimit"
+	"github.com/halimath/httputils/response"
+	"github.com/halimath/kvlog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var rateLimitedTotal = metrics.Default.NewCounterVec(prometheus.CounterOpts{
+	Name: "fate_table_rate_limited_requests_total",
+	Help: "Number of requests rejected due to rate limiting",
+}, []string{"group"})
+
+// rateLimits holds the limiters for the different route groups. A nil limiter disables rate limiting for the
+// group. Requests received from trustedProxies are keyed by the client IP given in X-Forwarded-For.
+type rateLimits struct {
+	auth, mutations, reads *ratelimit.Limiter
+	trustedProxies         []netip.Prefix
+}
+
+func newRateLimits(cfg config.Config) rateLimits {
+	return rateLimits{
+		auth:           newLimiter(cfg.RateLimitAuthPerMinute, cfg.RateLimitAuthBurst),
+		mutations:      newLimiter(cfg.RateLimitMutationsPerMinute, cfg.RateLimitMutationsBurst),
+		reads:          newLimiter(cfg.RateLimitReadsPerMinute, cfg.RateLimitReadsBurst),
+		trustedProxies: cfg.TrustedProxyPrefixes(),
+	}
+}
+
+func newLimiter(perMinute, burst int) *ratelimit.Limiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return ratelimit.New(perMinute, burst)
+}
+
+// authGroup selects the limiter for the token minting routes.
+func (l rateLimits) authGroup(*http.Request) (string, *ratelimit.Limiter) {
+	return "auth", l.auth
+}
+
+// sessionGroup selects the limiter for session routes based on whether the request reads or mutates data.
+func (l rateLimits) sessionGroup(r *http.Request) (string, *ratelimit.Limiter) {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return "reads", l.reads
+	}
+	return "mutations", l.mutations
+}
+
+// middleware rejects requests exceeding the limit returned by selectLimiter. Requests are keyed by the
+// authenticated user's id or the client's IP address for unauthenticated requests.
+func (l rateLimits) middleware(selectLimiter func(*http.Request) (string, *ratelimit.Limiter)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			group, limiter := selectLimiter(r)
+			if limiter == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key, ok := auth.UserID(r.Context())
+			if ok {
+				key = "user:" + key
+			} else {
+				key = "ip:" + l.clientIP(r)
+			}
+
+			if ok, retryAfter := limiter.Allow(key); !ok {
+				rateLimitedTotal.WithLabelValues(group).Inc()
+				kvlog.FromContext(r.Context()).Logs("rate limit exceeded", kvlog.WithKV("group", group), kvlog.WithKV("key", key))
+				sendTooManyRequests(w, r, retryAfter)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns the IP address of the client that sent r. If r has been received from a trusted proxy, the
+// rightmost address in X-Forwarded-For not belonging to a trusted proxy is returned. Addresses further left
+// are set by the client and cannot be trusted.
+func (l rateLimits) clientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	if !l.trusted(ip) {
+		return ip
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(forwarded[i])
+		if len(addr) == 0 {
+			continue
+		}
+
+		ip = addr
+		if !l.trusted(ip) {
+			break
+		}
+	}
+
+	return ip
+}
+
+// trusted reports whether addr belongs to a trusted proxy.
+func (l rateLimits) trusted(addr string) bool {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+
+	ip = ip.Unmap()
+	return slices.ContainsFunc(l.trustedProxies, func(p netip.Prefix) bool { return p.Contains(ip) })
+}
+
+func sendTooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration) error {
+	return response.Problem(w, r, response.ProblemDetails{
+		Type:   "https://github.com/halimath/fate-table/problem/tooManyRequests",
+		Title:  "Too many requests",
+		Status: http.StatusTooManyRequests,
+		Detail: "The rate limit has been exceeded. Retry after the duration given in the Retry-After header.",
+	}, response.AddHeader("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))))
+}
@@ -0,0 +1,41 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+)
+
+func TestRateLimits_clientIP(t *testing.T) {
+	l := newRateLimits(config.Config{TrustedProxies: "10.0.0.1, 192.168.0.0/16"})
+
+	type test struct {
+		remoteAddr, forwardedFor, want string
+	}
+
+	tests := []test{
+		{"203.0.113.7:1234", "", "203.0.113.7"},
+		{"203.0.113.7:1234", "198.51.100.1", "203.0.113.7"},
+		{"10.0.0.1:1234", "", "10.0.0.1"},
+		{"10.0.0.1:1234", "198.51.100.1", "198.51.100.1"},
+		{"10.0.0.1:1234", "198.51.100.2, 198.51.100.1, 192.168.1.1", "198.51.100.1"},
+		{"10.0.0.1:1234", "192.168.1.2, 192.168.1.1", "192.168.1.2"},
+		{"[::ffff:10.0.0.1]:1234", "198.51.100.1", "198.51.100.1"},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/api/auth/new", nil)
+		r.RemoteAddr = test.remoteAddr
+		if len(test.forwardedFor) > 0 {
+			r.Header.Set("X-Forwarded-For", test.forwardedFor)
+		}
+
+		expect.WithMessage(t, "%s %q", test.remoteAddr, test.forwardedFor).That(
+			is.EqualTo(l.clientIP(r), test.want),
+		)
+	}
+}
@@ -14,34 +14,83 @@ func Provide(
 	cfg config.Config, logger kvlog.Logger, version, commit string,
 	tokenHandler auth.TokenHandler,
 	createSession usecase.CreateSession,
+	listSessions usecase.ListSessions,
 	loadSession usecase.LoadSession,
+	updateSession usecase.UpdateSession,
+	archiveSession usecase.ArchiveSession,
+	deleteSession usecase.DeleteSession,
+	undoSession usecase.UndoSession,
+	redoSession usecase.RedoSession,
+	listSessionEvents usecase.ListSessionEvents,
+	loadSessionAt usecase.LoadSessionAt,
 	joinSession usecase.JoinSession,
+	spectateSession usecase.SpectateSession,
+	createInvite usecase.CreateInvite,
+	listInvites usecase.ListInvites,
+	revokeInvite usecase.RevokeInvite,
+	kickPlayer usecase.KickPlayer,
+	listBans usecase.ListBans,
+	liftBan usecase.LiftBan,
+	setMemberRole usecase.SetMemberRole,
 	createAspect usecase.CreateAspect,
 	createCharacterAspect usecase.CreateCharacterAspect,
 	deleteAspect usecase.DeleteAspect,
+	setAspectVisibility usecase.SetAspectVisibility,
+	setCharacterVisibility usecase.SetCharacterVisibility,
 	updateFatePoints usecase.UpdateFatePoints,
-) http.Handler {
+) (http.Handler, error) {
 	versionInfo := VersionInfo{
 		Version:    version,
 		Commit:     commit,
 		ApiVersion: "1.0.0",
 	}
 
+	limits := newRateLimits(cfg)
+	idempotencyKeys := newIdempotencyStore(cfg)
+
+	specValidator, err := newSpecValidator(cfg.DevMode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Requests are validated against the spec after authentication and rate limiting so that unauthenticated
+	// clients learn nothing about the API's structure. The validator matches routes using the full path and
+	// thus runs before stripping the prefix.
+	validate := specValidator.middleware
+
 	mux := http.NewServeMux()
-	mux.Handle("/api/auth/", http.StripPrefix("/api/auth", newAuthMux(tokenHandler)))
-	mux.Handle("/api/sessions/", http.StripPrefix("/api/sessions", authMiddleware(tokenHandler)(newSessionAPIHandler(
+	mux.Handle("/api/auth/", mountedAt("/api/auth", limits.middleware(limits.authGroup)(validate(http.StripPrefix("/api/auth", newAuthMux(tokenHandler))))))
+	mux.Handle("/api/sessions/", mountedAt("/api/sessions", authMiddleware(tokenHandler)(limits.middleware(limits.sessionGroup)(validate(http.StripPrefix("/api/sessions", idempotencyMiddleware(idempotencyKeys)(newSessionAPIHandler(
 		cfg,
 		createSession,
+		listSessions,
 		loadSession,
+		updateSession,
+		archiveSession,
+		deleteSession,
+		undoSession,
+		redoSession,
+		listSessionEvents,
+		loadSessionAt,
 		joinSession,
+		spectateSession,
+		createInvite,
+		listInvites,
+		revokeInvite,
+		kickPlayer,
+		listBans,
+		liftBan,
+		setMemberRole,
 		createAspect,
 		createCharacterAspect,
 		deleteAspect,
+		setAspectVisibility,
+		setCharacterVisibility,
 		updateFatePoints,
-	))))
-	mux.HandleFunc("GET /api/version-info", func(w http.ResponseWriter, r *http.Request) {
+	))))))))
+	mux.Handle("GET /api/version-info", routed("GET /api/version-info", validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response.JSON(w, r, versionInfo)
-	})
+	}))))
 
-	return mux
+	return requestMetricsMiddleware(tracingMiddleware(corsMiddleware(cfg.CORSOrigins())(mux))), nil
 }
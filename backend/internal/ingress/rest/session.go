@@ -2,12 +2,16 @@
 package rest
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
 	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
 	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
 	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
@@ -19,27 +23,65 @@ import (
 func newSessionAPIHandler(
 	cfg config.Config,
 	createSession usecase.CreateSession,
+	listSessions usecase.ListSessions,
 	loadSession usecase.LoadSession,
+	updateSession usecase.UpdateSession,
+	archiveSession usecase.ArchiveSession,
+	deleteSession usecase.DeleteSession,
+	undoSession usecase.UndoSession,
+	redoSession usecase.RedoSession,
+	listSessionEvents usecase.ListSessionEvents,
+	loadSessionAt usecase.LoadSessionAt,
 	joinSession usecase.JoinSession,
+	spectateSession usecase.SpectateSession,
+	createInvite usecase.CreateInvite,
+	listInvites usecase.ListInvites,
+	revokeInvite usecase.RevokeInvite,
+	kickPlayer usecase.KickPlayer,
+	listBans usecase.ListBans,
+	liftBan usecase.LiftBan,
+	setMemberRole usecase.SetMemberRole,
 	createAspect usecase.CreateAspect,
 	createCharacterAspect usecase.CreateCharacterAspect,
 	deleteAspect usecase.DeleteAspect,
+	setAspectVisibility usecase.SetAspectVisibility,
+	setCharacterVisibility usecase.SetCharacterVisibility,
 	updateFatePoints usecase.UpdateFatePoints,
 ) http.Handler {
-	mux := errmux.NewServeMux()
+	mux := newRouteMux()
 	mux.ErrorHandler = handleError
 
 	mux.Handle("POST /", createSessionHandler(createSession))
+	mux.Handle("GET /{$}", listSessionsHandler(listSessions))
 	mux.Handle("GET /{id}", getSessionHandler(cfg, loadSession))
+	mux.Handle("PATCH /{id}", updateSessionHandler(updateSession))
+	mux.Handle("DELETE /{id}", deleteSessionHandler(deleteSession))
+	mux.Handle("PUT /{id}/archive", archiveSessionHandler(archiveSession, true))
+	mux.Handle("DELETE /{id}/archive", archiveSessionHandler(archiveSession, false))
+	mux.Handle("POST /{id}/undo", historyHandler(usecase.UCNoRet[string](undoSession)))
+	mux.Handle("POST /{id}/redo", historyHandler(usecase.UCNoRet[string](redoSession)))
+	mux.Handle("GET /{id}/events", listSessionEventsHandler(listSessionEvents))
+	mux.Handle("GET /{id}/versions/{version}", loadSessionAtHandler(loadSessionAt))
 	mux.Handle("POST /{id}/join", joinSessionHandler(joinSession))
+	mux.Handle("POST /{id}/spectate", spectateSessionHandler(spectateSession))
+	mux.Handle("GET /{id}/invites", listInvitesHandler(listInvites))
+	mux.Handle("POST /{id}/invites", createInviteHandler(createInvite))
+	mux.Handle("DELETE /{id}/invites/{inviteID}", revokeInviteHandler(revokeInvite))
+	mux.Handle("DELETE /{id}/players/{userID}", kickPlayerHandler(kickPlayer, false))
+	mux.Handle("GET /{id}/bans", listBansHandler(listBans))
+	mux.Handle("PUT /{id}/bans/{userID}", kickPlayerHandler(kickPlayer, true))
+	mux.Handle("DELETE /{id}/bans/{userID}", liftBanHandler(liftBan))
+	mux.Handle("PUT /{id}/members/{userID}/role", setMemberRoleHandler(setMemberRole))
 	mux.Handle("POST /{id}/aspects", createAspectHandler(createAspect))
 	mux.Handle("POST /{id}/characters/{characterID}/aspects", createCharacterAspectHandler(createCharacterAspect))
 	mux.Handle("DELETE /{id}/aspects/{aspectID}", deleteAspectHandler(deleteAspect))
+	mux.Handle("PUT /{id}/aspects/{aspectID}/visibility", setAspectVisibilityHandler(setAspectVisibility))
+	mux.Handle("PUT /{id}/characters/{characterID}/visibility", setCharacterVisibilityHandler(setCharacterVisibility))
 	// mux.HandleFunc("POST /{id}/characters", wrapper.CreateCharacter)
 	// mux.HandleFunc("DELETE /{id}/characters/{characterId}", wrapper.DeleteCharacter)
 	mux.Handle("PUT /{id}/characters/{characterID}/fatepoints", updateFatePointsHandler(updateFatePoints))
 
-	return mux
+	return ifMatchMiddleware(versionETagMiddleware(mux))
 }
 
 func updateFatePointsHandler(updateFatePoints usecase.UpdateFatePoints) errmux.Handler {
@@ -51,7 +93,7 @@ func updateFatePointsHandler(updateFatePoints usecase.UpdateFatePoints) errmux.H
 				Type:   "github.com/halimath/fate-table/problem/invalidUpdateFatePoints",
 				Title:  "Invalid request payload to update fate points",
 				Status: http.StatusBadRequest,
-				Errors: []any{err},
+				Errors: []any{err.Error()},
 			})
 		}
 
@@ -84,6 +126,67 @@ func deleteAspectHandler(deleteAspect usecase.DeleteAspect) errmux.Handler {
 	})
 }
 
+func setAspectVisibilityHandler(setAspectVisibility usecase.SetAspectVisibility) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		visibility, err := bindVisibility(r)
+		if err != nil {
+			return response.Problem(w, r, response.ProblemDetails{
+				Type:   "https://github.com/halimath/fate-table/problem/invalidVisibility",
+				Title:  "Invalid request payload to set visibility",
+				Status: http.StatusBadRequest,
+				Errors: []any{err.Error()},
+			})
+		}
+
+		err = setAspectVisibility(r.Context(), usecase.SetAspectVisibilityRequest{
+			SessionID:  r.PathValue("id"),
+			AspectID:   r.PathValue("aspectID"),
+			Visibility: visibility,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return response.NoContent(w, r)
+	})
+}
+
+func setCharacterVisibilityHandler(setCharacterVisibility usecase.SetCharacterVisibility) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		visibility, err := bindVisibility(r)
+		if err != nil {
+			return response.Problem(w, r, response.ProblemDetails{
+				Type:   "https://github.com/halimath/fate-table/problem/invalidVisibility",
+				Title:  "Invalid request payload to set visibility",
+				Status: http.StatusBadRequest,
+				Errors: []any{err.Error()},
+			})
+		}
+
+		err = setCharacterVisibility(r.Context(), usecase.SetCharacterVisibilityRequest{
+			SessionID:   r.PathValue("id"),
+			CharacterID: r.PathValue("characterID"),
+			Visibility:  visibility,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return response.NoContent(w, r)
+	})
+}
+
+func bindVisibility(r *http.Request) (session.Visibility, error) {
+	var body Visibility
+	if err := bindBody(r, &body); err != nil {
+		return session.Visibility{}, err
+	}
+
+	return convertVisibilityFromDTO(&body)
+}
+
 func createCharacterAspectHandler(createCharacterAspect usecase.CreateCharacterAspect) errmux.Handler {
 	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
 		var body CreateAspect
@@ -93,22 +196,25 @@ func createCharacterAspectHandler(createCharacterAspect usecase.CreateCharacterA
 				Type:   "github.com/halimath/fate-table/problem/invalidCreateAspect",
 				Title:  "Invalid request payload to create aspect",
 				Status: http.StatusBadRequest,
-				Errors: []any{err},
+				Errors: []any{err.Error()},
 			})
 		}
 
-		if body.Name == "" {
+		visibility, err := convertVisibilityFromDTO(body.Visibility)
+		if err != nil {
 			return response.Problem(w, r, response.ProblemDetails{
 				Type:   "github.com/halimath/fate-table/problem/invalidCreateAspect",
-				Title:  "Missing aspect name",
+				Title:  "Invalid aspect visibility",
 				Status: http.StatusBadRequest,
+				Errors: []any{err.Error()},
 			})
 		}
 
 		aspectID, err := createCharacterAspect(r.Context(), usecase.CreateCharacterAspectRequest{
 			CreateAspectRequest: usecase.CreateAspectRequest{
-				SessionID: r.PathValue("id"),
-				Name:      body.Name,
+				SessionID:  r.PathValue("id"),
+				Name:       body.Name,
+				Visibility: visibility,
 			},
 			CharacterID: r.PathValue("characterID"),
 		})
@@ -131,21 +237,24 @@ func createAspectHandler(createAspect usecase.CreateAspect) errmux.Handler {
 				Type:   "github.com/halimath/fate-table/problem/invalidCreateAspect",
 				Title:  "Invalid request payload to create aspect",
 				Status: http.StatusBadRequest,
-				Errors: []any{err},
+				Errors: []any{err.Error()},
 			})
 		}
 
-		if body.Name == "" {
+		visibility, err := convertVisibilityFromDTO(body.Visibility)
+		if err != nil {
 			return response.Problem(w, r, response.ProblemDetails{
 				Type:   "github.com/halimath/fate-table/problem/invalidCreateAspect",
-				Title:  "Missing aspect name",
+				Title:  "Invalid aspect visibility",
 				Status: http.StatusBadRequest,
+				Errors: []any{err.Error()},
 			})
 		}
 
 		aspectID, err := createAspect(r.Context(), usecase.CreateAspectRequest{
-			SessionID: r.PathValue("id"),
-			Name:      body.Name,
+			SessionID:  r.PathValue("id"),
+			Name:       body.Name,
+			Visibility: visibility,
 		})
 
 		if err != nil {
@@ -165,28 +274,188 @@ func joinSessionHandler(joinSession usecase.JoinSession) errmux.Handler {
 				Type:   "github.com/halimath/fate-table/problem/invalidJoinSession",
 				Title:  "Invalid request payload to join a session",
 				Status: http.StatusBadRequest,
-				Errors: []any{err},
+				Errors: []any{err.Error()},
 			})
 		}
 
-		if body.Name == "" {
+		req := usecase.JoinSessionRequest{
+			SessionID:     r.PathValue("id"),
+			CharacterName: body.Name,
+		}
+		if body.Invite != nil {
+			req.InviteID = *body.Invite
+		}
+
+		characterID, err := joinSession(r.Context(), req)
+
+		if err != nil {
+			return err
+		}
+
+		return response.PlainText(w, r, characterID)
+	})
+}
+
+func spectateSessionHandler(spectateSession usecase.SpectateSession) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		var body SpectateSession
+		if err := bindBody(r, &body); err != nil {
 			return response.Problem(w, r, response.ProblemDetails{
-				Type:   "github.com/halimath/fate-table/problem/invalidJoinSession",
-				Title:  "Missing character name",
+				Type:   "https://github.com/halimath/fate-table/problem/invalidSpectateSession",
+				Title:  "Invalid request payload to spectate a session",
 				Status: http.StatusBadRequest,
+				Errors: []any{err.Error()},
 			})
 		}
 
-		characterID, err := joinSession(r.Context(), usecase.JoinSessionRequest{
-			SessionID:     r.PathValue("id"),
-			CharacterName: body.Name,
+		err := spectateSession(r.Context(), usecase.SpectateSessionRequest{
+			SessionID: r.PathValue("id"),
+			InviteID:  body.Invite,
 		})
 
 		if err != nil {
 			return err
 		}
 
-		return response.PlainText(w, r, characterID)
+		return response.NoContent(w, r)
+	})
+}
+
+func createInviteHandler(createInvite usecase.CreateInvite) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		var body CreateInvite
+		if err := bindBody(r, &body); err != nil {
+			return response.Problem(w, r, response.ProblemDetails{
+				Type:   "https://github.com/halimath/fate-table/problem/invalidCreateInvite",
+				Title:  "Invalid request payload to create an invite",
+				Status: http.StatusBadRequest,
+				Errors: []any{err.Error()},
+			})
+		}
+
+		req := usecase.CreateInviteRequest{
+			SessionID: r.PathValue("id"),
+		}
+		if body.ExpiresIn != nil {
+			req.TTL = time.Duration(*body.ExpiresIn) * time.Second
+		}
+		if body.MaxUses != nil {
+			req.MaxUses = *body.MaxUses
+		}
+		if body.Role != nil && *body.Role == InviteRoleSpectator {
+			req.Role = session.Spectator
+		}
+
+		invite, err := createInvite(r.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return response.PlainText(w, r, invite.ID, response.StatusCode(http.StatusCreated))
+	})
+}
+
+func listInvitesHandler(listInvites usecase.ListInvites) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		invites, err := listInvites(r.Context(), r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		return response.JSON(w, r, convertInvites(invites), response.AddHeader("Cache-Control", "no-store"))
+	})
+}
+
+func revokeInviteHandler(revokeInvite usecase.RevokeInvite) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		err := revokeInvite(r.Context(), usecase.RevokeInviteRequest{
+			SessionID: r.PathValue("id"),
+			InviteID:  r.PathValue("inviteID"),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return response.NoContent(w, r)
+	})
+}
+
+func kickPlayerHandler(kickPlayer usecase.KickPlayer, ban bool) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		err := kickPlayer(r.Context(), usecase.KickPlayerRequest{
+			SessionID: r.PathValue("id"),
+			UserID:    r.PathValue("userID"),
+			Ban:       ban,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return response.NoContent(w, r)
+	})
+}
+
+func listBansHandler(listBans usecase.ListBans) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		bans, err := listBans(r.Context(), r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		return response.JSON(w, r, convertBans(bans), response.AddHeader("Cache-Control", "no-store"))
+	})
+}
+
+func liftBanHandler(liftBan usecase.LiftBan) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		err := liftBan(r.Context(), usecase.LiftBanRequest{
+			SessionID: r.PathValue("id"),
+			UserID:    r.PathValue("userID"),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return response.NoContent(w, r)
+	})
+}
+
+func setMemberRoleHandler(setMemberRole usecase.SetMemberRole) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		var body SetMemberRole
+		if err := bindBody(r, &body); err != nil {
+			return response.Problem(w, r, response.ProblemDetails{
+				Type:   "https://github.com/halimath/fate-table/problem/invalidSetMemberRole",
+				Title:  "Invalid request payload to set a member's role",
+				Status: http.StatusBadRequest,
+				Errors: []any{err.Error()},
+			})
+		}
+
+		role, err := convertRole(body.Role)
+		if err != nil {
+			return response.Problem(w, r, response.ProblemDetails{
+				Type:   "https://github.com/halimath/fate-table/problem/invalidSetMemberRole",
+				Title:  "Invalid role",
+				Status: http.StatusBadRequest,
+				Errors: []any{err.Error()},
+			})
+		}
+
+		err = setMemberRole(r.Context(), usecase.SetMemberRoleRequest{
+			SessionID: r.PathValue("id"),
+			UserID:    r.PathValue("userID"),
+			Role:      role,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return response.NoContent(w, r)
 	})
 }
 
@@ -198,7 +467,7 @@ func createSessionHandler(createSession usecase.CreateSession) errmux.Handler {
 				Type:   "github.com/halimath/fate-table/problem/invalidSessionCreate",
 				Title:  "Invalid session creation payload",
 				Status: http.StatusBadRequest,
-				Errors: []any{err},
+				Errors: []any{err.Error()},
 			})
 		}
 
@@ -214,6 +483,133 @@ func createSessionHandler(createSession usecase.CreateSession) errmux.Handler {
 	})
 }
 
+func listSessionsHandler(listSessions usecase.ListSessions) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		sessions, err := listSessions(r.Context(), struct{}{})
+		if err != nil {
+			return err
+		}
+
+		return response.JSON(w, r, convertSessionSummaries(sessions), response.AddHeader("Cache-Control", "no-store"))
+	})
+}
+
+func updateSessionHandler(updateSession usecase.UpdateSession) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		var body UpdateSession
+		if err := bindBody(r, &body); err != nil {
+			return response.Problem(w, r, response.ProblemDetails{
+				Type:   "https://github.com/halimath/fate-table/problem/invalidSessionUpdate",
+				Title:  "Invalid session update payload",
+				Status: http.StatusBadRequest,
+				Errors: []any{err.Error()},
+			})
+		}
+
+		err := updateSession(r.Context(), usecase.UpdateSessionRequest{
+			SessionID: r.PathValue("id"),
+			Title:     body.Title,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return response.NoContent(w, r)
+	})
+}
+
+func archiveSessionHandler(archiveSession usecase.ArchiveSession, archived bool) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		err := archiveSession(r.Context(), usecase.ArchiveSessionRequest{
+			SessionID: r.PathValue("id"),
+			Archived:  archived,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return response.NoContent(w, r)
+	})
+}
+
+func historyHandler(op usecase.UCNoRet[string]) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		if err := op(r.Context(), r.PathValue("id")); err != nil {
+			return err
+		}
+
+		return response.NoContent(w, r)
+	})
+}
+
+func listSessionEventsHandler(listSessionEvents usecase.ListSessionEvents) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		var since uint64
+		if v := r.URL.Query().Get("since"); len(v) > 0 {
+			var err error
+			if since, err = strconv.ParseUint(v, 10, 64); err != nil {
+				return sendInvalidParameter(w, r, "since", err)
+			}
+		}
+
+		records, err := listSessionEvents(r.Context(), usecase.ListSessionEventsRequest{
+			SessionID: r.PathValue("id"),
+			Since:     since,
+		})
+		if err != nil {
+			return err
+		}
+
+		events, err := convertRecords(records)
+		if err != nil {
+			return err
+		}
+
+		return response.JSON(w, r, events, response.AddHeader("Cache-Control", "no-store"))
+	})
+}
+
+func loadSessionAtHandler(loadSessionAt usecase.LoadSessionAt) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		version, err := strconv.ParseUint(r.PathValue("version"), 10, 64)
+		if err != nil {
+			return sendInvalidParameter(w, r, "version", err)
+		}
+
+		ses, err := loadSessionAt(r.Context(), usecase.LoadSessionAtRequest{
+			SessionID: r.PathValue("id"),
+			Version:   version,
+		})
+		if err != nil {
+			return err
+		}
+
+		return response.JSON(w, r, convertSession(ses), response.AddHeader("Cache-Control", "no-store"))
+	})
+}
+
+func sendInvalidParameter(w http.ResponseWriter, r *http.Request, name string, err error) error {
+	return response.Problem(w, r, response.ProblemDetails{
+		Type:   "https://github.com/halimath/fate-table/problem/invalidRequest",
+		Title:  "Invalid request",
+		Status: http.StatusBadRequest,
+		Detail: fmt.Sprintf("Invalid parameter %s.", name),
+		Errors: []any{err.Error()},
+	})
+}
+
+func deleteSessionHandler(deleteSession usecase.DeleteSession) errmux.Handler {
+	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		if err := deleteSession(r.Context(), r.PathValue("id")); err != nil {
+			return err
+		}
+
+		return response.NoContent(w, r)
+	})
+}
+
 func getSessionHandler(cfg config.Config, loadSession usecase.LoadSession) errmux.Handler {
 	return errmux.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
 		sessionID := r.PathValue("id")
@@ -222,14 +618,24 @@ func getSessionHandler(cfg config.Config, loadSession usecase.LoadSession) errmu
 			return err
 		}
 
-		ifModifiedSince := r.Header.Get("If-Modified-Since")
-		if len(ifModifiedSince) > 0 {
+		userID, _ := auth.UserID(r.Context())
+		activity.observe(sessionID, userID)
+
+		etag := formatETag(ses.Version)
+
+		if ifNoneMatch := r.Header.Get("If-None-Match"); len(ifNoneMatch) > 0 {
+			// If-None-Match takes precedence over If-Modified-Since.
+			tags, wildcard := parseETags(ifNoneMatch)
+			if wildcard || matchesWeak(tags, etag) {
+				return response.NotModified(w, r, response.AddHeader("ETag", etag))
+			}
+		} else if ifModifiedSince := r.Header.Get("If-Modified-Since"); len(ifModifiedSince) > 0 {
 			ifModifiedSinceTime, err := http.ParseTime(ifModifiedSince)
 			if err != nil {
 				kvlog.FromContext(r.Context()).Logs("failed to parse If-Modified-Since header", kvlog.WithErr(err))
 			} else {
 				if !ses.LastModified.UTC().Truncate(time.Second).After(ifModifiedSinceTime.UTC().Truncate(time.Second)) {
-					return response.NotModified(w, r)
+					return response.NotModified(w, r, response.AddHeader("ETag", etag))
 				}
 			}
 		}
@@ -244,6 +650,7 @@ func getSessionHandler(cfg config.Config, loadSession usecase.LoadSession) errmu
 
 		return response.JSON(w, r, convertSession(ses),
 			response.AddHeader("Last-Modified", ses.LastModified.UTC().Truncate(time.Second).Format(http.TimeFormat)),
+			response.AddHeader("ETag", etag),
 			cacheHeaderOption,
 		)
 	})
@@ -260,6 +667,121 @@ func handleError(w http.ResponseWriter, r *http.Request, err error) {
 		return
 	}
 
+	if errors.Is(err, usecase.ErrInvalidRole) {
+		response.Problem(w, r, response.ProblemDetails{
+			Type:   "https://github.com/halimath/fate-table/problem/invalidRole",
+			Title:  "Invalid role",
+			Status: http.StatusBadRequest,
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	var validationErr *usecase.ValidationError
+	if errors.As(err, &validationErr) {
+		errs := make([]any, len(validationErr.Fields))
+		for i, f := range validationErr.Fields {
+			errs[i] = FieldError{
+				Field:   f.Field,
+				Message: f.Message,
+			}
+		}
+
+		response.Problem(w, r, response.ProblemDetails{
+			Type:   "https://github.com/halimath/fate-table/problem/validationFailed",
+			Title:  "Validation failed",
+			Status: http.StatusUnprocessableEntity,
+			Detail: "One or more fields of the request are invalid.",
+			Errors: errs,
+		})
+		return
+	}
+
+	if errors.Is(err, usecase.ErrInvalidCharacter) {
+		response.Problem(w, r, response.ProblemDetails{
+			Type:   "https://github.com/halimath/fate-table/problem/invalidCharacter",
+			Title:  "Invalid character",
+			Status: http.StatusUnprocessableEntity,
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, usecase.ErrQuotaExceeded) {
+		response.Problem(w, r, response.ProblemDetails{
+			Type:   "https://github.com/halimath/fate-table/problem/quotaExceeded",
+			Title:  "Quota exceeded",
+			Status: http.StatusUnprocessableEntity,
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, usecase.ErrPreconditionFailed) {
+		sendPreconditionFailed(w, r)
+		return
+	}
+
+	if errors.Is(err, usecase.ErrArchived) {
+		response.Problem(w, r, response.ProblemDetails{
+			Type:   "https://github.com/halimath/fate-table/problem/sessionArchived",
+			Title:  "Session archived",
+			Status: http.StatusConflict,
+			Detail: "The session has been archived and is read-only.",
+		})
+		return
+	}
+
+	if errors.Is(err, usecase.ErrNothingToUndo) {
+		response.Problem(w, r, response.ProblemDetails{
+			Type:   "https://github.com/halimath/fate-table/problem/nothingToUndo",
+			Title:  "Nothing to undo",
+			Status: http.StatusConflict,
+			Detail: "The session's history contains no change you can undo.",
+		})
+		return
+	}
+
+	if errors.Is(err, usecase.ErrNothingToRedo) {
+		response.Problem(w, r, response.ProblemDetails{
+			Type:   "https://github.com/halimath/fate-table/problem/nothingToRedo",
+			Title:  "Nothing to redo",
+			Status: http.StatusConflict,
+			Detail: "The session's history contains no undone change you can redo.",
+		})
+		return
+	}
+
+	if errors.Is(err, usecase.ErrInvalidInvite) {
+		response.Problem(w, r, response.ProblemDetails{
+			Type:   "https://github.com/halimath/fate-table/problem/invalidInvite",
+			Title:  "Invalid invite",
+			Status: http.StatusForbidden,
+			Detail: "The invite code is either unknown, expired or has been used up.",
+		})
+		return
+	}
+
+	if errors.Is(err, usecase.ErrHistoryUnavailable) {
+		response.Problem(w, r, response.ProblemDetails{
+			Type:   "https://github.com/halimath/fate-table/problem/historyUnavailable",
+			Title:  "History unavailable",
+			Status: http.StatusNotImplemented,
+			Detail: "The history of sessions is only recorded by the event-sourced session store.",
+		})
+		return
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		response.Problem(w, r, response.ProblemDetails{
+			Type:   "https://github.com/halimath/fate-table/problem/timeout",
+			Title:  "Timeout",
+			Status: http.StatusServiceUnavailable,
+			Detail: "The request could not be completed in time. Please retry.",
+		})
+		return
+	}
+
 	response.Error(w, r, err)
 }
 
@@ -274,16 +796,57 @@ func handleError(w http.ResponseWriter, r *http.Request, err error) {
 // 	}
 // }
 
+func convertRole(r SetMemberRoleRole) (session.Role, error) {
+	switch r {
+	case SetMemberRoleRoleCoGM:
+		return session.CoGM, nil
+	case SetMemberRoleRolePlayer:
+		return session.Player, nil
+	default:
+		return session.NoRole, fmt.Errorf("invalid role: %s", r)
+	}
+}
+
 func convertSession(s session.Session) Session {
 	return Session{
 		Id:         s.ID,
 		OwnerId:    s.OwnerID,
 		Title:      s.Title,
+		Archived:   s.Archived,
+		Members:    convertMembers(s.Members()),
 		Aspects:    convertAspects(s.Aspects),
 		Characters: convertCharacters(s.Characters),
 	}
 }
 
+func convertSessionSummaries(ss []session.Session) []SessionSummary {
+	res := make([]SessionSummary, len(ss))
+
+	for i, s := range ss {
+		res[i] = SessionSummary{
+			Id:           s.ID,
+			Title:        s.Title,
+			Archived:     s.Archived,
+			LastModified: s.LastModified,
+		}
+	}
+
+	return res
+}
+
+func convertMembers(ms []session.RoleAssignment) []Member {
+	res := make([]Member, len(ms))
+
+	for i, m := range ms {
+		res[i] = Member{
+			UserId: m.UserID,
+			Role:   MemberRole(m.Role.String()),
+		}
+	}
+
+	return res
+}
+
 func convertCharacters(cs []session.Character) []Character {
 	if len(cs) == 0 {
 		return []Character{}
@@ -298,6 +861,7 @@ func convertCharacters(cs []session.Character) []Character {
 			Id:         c.ID,
 			OwnerId:    c.OwnerID,
 			FatePoints: c.FatePoints,
+			Visibility: convertVisibility(c.Visibility),
 			Aspects:    convertAspects(c.Aspects),
 		}
 	}
@@ -314,8 +878,118 @@ func convertAspects(a []session.Aspect) []Aspect {
 
 	for i, aspect := range a {
 		res[i] = Aspect{
-			Id:   aspect.ID,
-			Name: aspect.Name,
+			Id:         aspect.ID,
+			Name:       aspect.Name,
+			Visibility: convertVisibility(aspect.Visibility),
+		}
+	}
+
+	return res
+}
+
+func convertVisibility(v session.Visibility) Visibility {
+	var res Visibility
+
+	switch v.Level {
+	case session.GMOnly:
+		res.Level = VisibilityLevelGMOnly
+	case session.SelectedCharacters:
+		res.Level = VisibilityLevelSelectedCharacters
+		// CharacterIDs have been removed for players who must not learn who else can see the data.
+		if len(v.CharacterIDs) > 0 {
+			characterIDs := append([]string{}, v.CharacterIDs...)
+			res.CharacterIds = &characterIDs
+		}
+	default:
+		res.Level = VisibilityLevelPublic
+	}
+
+	return res
+}
+
+func convertVisibilityFromDTO(v *Visibility) (session.Visibility, error) {
+	if v == nil {
+		return session.Visibility{}, nil
+	}
+
+	switch v.Level {
+	case VisibilityLevelPublic:
+		return session.Visibility{Level: session.Public}, nil
+	case VisibilityLevelGMOnly:
+		return session.Visibility{Level: session.GMOnly}, nil
+	case VisibilityLevelSelectedCharacters:
+		if v.CharacterIds == nil || len(*v.CharacterIds) == 0 {
+			return session.Visibility{}, fmt.Errorf("missing character ids for visibility level %s", v.Level)
+		}
+
+		return session.Visibility{
+			Level:        session.SelectedCharacters,
+			CharacterIDs: *v.CharacterIds,
+		}, nil
+	default:
+		return session.Visibility{}, fmt.Errorf("invalid visibility level: %s", v.Level)
+	}
+}
+
+func convertInvites(is []session.Invite) []Invite {
+	res := make([]Invite, len(is))
+
+	for i, invite := range is {
+		res[i] = Invite{
+			Id:      invite.ID,
+			Role:    InviteRole(invite.Role.String()),
+			Created: invite.Created,
+			Uses:    invite.Uses,
+		}
+
+		if !invite.Expires.IsZero() {
+			expires := invite.Expires
+			res[i].Expires = &expires
+		}
+
+		if invite.MaxUses > 0 {
+			maxUses := invite.MaxUses
+			res[i].MaxUses = &maxUses
+		}
+	}
+
+	return res
+}
+
+// convertRecords converts rs to their API representation. An event's payload is its JSON encoding.
+func convertRecords(rs []session.Record) ([]SessionEvent, error) {
+	res := make([]SessionEvent, len(rs))
+
+	for i, rec := range rs {
+		encoded, err := json.Marshal(rec.Event)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = SessionEvent{
+			Version: int64(rec.Version),
+			Time:    rec.Time,
+			Type:    rec.Event.Type(),
+		}
+		if err := json.Unmarshal(encoded, &res[i].Data); err != nil {
+			return nil, err
+		}
+		if len(rec.Actor) > 0 {
+			actor := rec.Actor
+			res[i].ActorId = &actor
+		}
+	}
+
+	return res, nil
+}
+
+func convertBans(bs []session.Ban) []Ban {
+	res := make([]Ban, len(bs))
+
+	for i, b := range bs {
+		res[i] = Ban{
+			UserId:  b.UserID,
+			Created: b.Created,
 		}
 	}
 
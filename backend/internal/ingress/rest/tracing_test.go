@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	sessions := newRouteMux()
+	sessions.HandleFunc("GET /{id}/bans", func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/test/sessions/", mountedAt("/test/sessions", http.StripPrefix("/test/sessions", sessions)))
+	h := requestMetricsMiddleware(tracingMiddleware(mux))
+
+	r := httptest.NewRequest(http.MethodGet, "/test/sessions/1/bans", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := exporter.GetSpans()
+	expect.That(t, is.EqualTo(len(spans), 1))
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, a := range spans[0].Attributes {
+		attrs[a.Key] = a.Value
+	}
+
+	expect.That(t,
+		is.EqualTo(spans[0].Name, "GET /test/sessions/{id}/bans"),
+		is.EqualTo(spans[0].SpanContext.TraceID().String(), "4bf92f3577b34da6a3ce929d0e0e4736"),
+		is.EqualTo(spans[0].Parent.SpanID().String(), "00f067aa0ba902b7"),
+		is.EqualTo(attrs["session.id"].AsString(), "1"),
+		is.EqualTo(attrs["http.route"].AsString(), "/test/sessions/{id}/bans"),
+		is.EqualTo(attrs["http.response.status_code"].AsInt64(), int64(http.StatusNoContent)),
+	)
+}
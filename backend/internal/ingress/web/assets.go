@@ -0,0 +1,221 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// cacheImmutable is sent for fingerprinted files whose name changes whenever their content does.
+	cacheImmutable = "public, max-age=31536000, immutable"
+	// cacheRevalidate is sent for all other files, most notably index.html, so that browsers pick up new
+	// releases right away. Revalidation is cheap due to ETags.
+	cacheRevalidate = "no-cache"
+
+	// minCompressSize defines the size below which files are not compressed on the fly.
+	minCompressSize = 256
+)
+
+// fingerprinted matches the names of files emitted by Vite with a content hash, e.g. assets/index-BkX3aP9q.js.
+var fingerprinted = regexp.MustCompile(`^assets/.+-[A-Za-z0-9_-]{8}\.[A-Za-z0-9]+$`)
+
+// encodings lists the supported content encodings in order of preference along with the file extension of
+// precompressed variants.
+var encodings = []struct{ name, ext string }{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// variant is a single representation of an asset.
+type variant struct {
+	encoding string
+	etag     string
+	data     []byte
+}
+
+// asset is a static file along with its precompressed variants.
+type asset struct {
+	name         string
+	contentType  string
+	cacheControl string
+	// variants contains the encoded variants in order of preference followed by the identity variant.
+	variants []variant
+}
+
+// negotiate selects the variant of a to serve for the given Accept-Encoding header.
+func (a *asset) negotiate(acceptEncoding string) variant {
+	for _, v := range a.variants {
+		if len(v.encoding) == 0 || accepts(acceptEncoding, v.encoding) {
+			return v
+		}
+	}
+	return a.variants[len(a.variants)-1]
+}
+
+// loadAssets reads all files from fsys. Files named like another file with an additional .br or .gz
+// extension are considered precompressed variants of that file. Compressible files without a gzip variant
+// are compressed once when loading.
+func loadAssets(fsys fs.FS) (map[string]*asset, error) {
+	files := make(map[string][]byte)
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		files[p], err = fs.ReadFile(fsys, p)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	assets := make(map[string]*asset, len(files))
+	for name, data := range files {
+		if isVariant(files, name) {
+			continue
+		}
+
+		a := &asset{
+			name:         name,
+			contentType:  contentType(name, data),
+			cacheControl: cacheRevalidate,
+		}
+		if fingerprinted.MatchString(name) {
+			a.cacheControl = cacheImmutable
+		}
+
+		hash := contentHash(data)
+		for _, enc := range encodings {
+			encoded, ok := files[name+enc.ext]
+			if !ok && enc.name == "gzip" && compressible(a.contentType) && len(data) >= minCompressSize {
+				encoded, ok = compress(data)
+			}
+			if ok {
+				a.variants = append(a.variants, variant{
+					encoding: enc.name,
+					etag:     strconv.Quote(hash + "-" + enc.name),
+					data:     encoded,
+				})
+			}
+		}
+		a.variants = append(a.variants, variant{etag: strconv.Quote(hash), data: data})
+
+		assets[name] = a
+	}
+
+	return assets, nil
+}
+
+// isVariant reports whether name is the precompressed variant of another file.
+func isVariant(files map[string][]byte, name string) bool {
+	for _, enc := range encodings {
+		if base, ok := strings.CutSuffix(name, enc.ext); ok {
+			if _, exists := files[base]; exists {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contentHash returns a short, URL-safe hash of data used to build strong ETags.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+func contentType(name string, data []byte) string {
+	if ct := mime.TypeByExtension(path.Ext(name)); len(ct) > 0 {
+		return ct
+	}
+	return http.DetectContentType(data)
+}
+
+// compressible reports whether content of the given type benefits from compression.
+func compressible(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "javascript") ||
+		strings.HasSuffix(mediaType, "json") ||
+		strings.HasSuffix(mediaType, "+xml") ||
+		mediaType == "application/wasm"
+}
+
+// compress gzips data. It reports false if compression does not reduce the size.
+func compress(data []byte) ([]byte, bool) {
+	var buf bytes.Buffer
+	w, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	w.Write(data)
+	w.Close()
+
+	if buf.Len() >= len(data) {
+		return nil, false
+	}
+	return buf.Bytes(), true
+}
+
+// accepts reports whether the Accept-Encoding header value acceptEncoding allows encoding.
+func accepts(acceptEncoding, encoding string) bool {
+	for _, token := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(token), ";")
+		coding = strings.TrimSpace(coding)
+		if !strings.EqualFold(coding, encoding) && coding != "*" {
+			continue
+		}
+
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// assetHandler serves assets negotiating their content encoding and supporting conditional and range
+// requests.
+type assetHandler map[string]*asset
+
+func (h assetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if len(name) == 0 {
+		name = "index.html"
+	}
+
+	a, ok := h[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	v := a.negotiate(r.Header.Get("Accept-Encoding"))
+
+	header := w.Header()
+	header.Set("Content-Type", a.contentType)
+	header.Set("Cache-Control", a.cacheControl)
+	header.Set("ETag", v.etag)
+	if len(a.variants) > 1 {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	if len(v.encoding) > 0 {
+		header.Set("Content-Encoding", v.encoding)
+	}
+
+	http.ServeContent(w, r, a.name, time.Time{}, bytes.NewReader(v.data))
+}
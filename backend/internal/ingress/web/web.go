@@ -19,13 +19,29 @@ func Provide() http.Handler {
 		panic(err)
 	}
 
+	h, err := newHandler(staticFilesFS)
+	if err != nil {
+		panic(err)
+	}
+
+	return h
+}
+
+// newHandler creates a handler serving the single page application from fsys.
+func newHandler(fsys fs.FS) (http.Handler, error) {
+	assets, err := loadAssets(fsys)
+	if err != nil {
+		return nil, err
+	}
+
 	pathRewriter, err := requesturi.RewritePath(map[string]string{
 		"/join/*":    "/",
+		"/join/*/*":  "/",
 		"/session/*": "/",
 	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return requesturi.Middleware(http.FileServer(http.FS(staticFilesFS)), pathRewriter)
+	return requesturi.Middleware(assetHandler(assets), pathRewriter), nil
 }
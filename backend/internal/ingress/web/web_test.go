@@ -0,0 +1,135 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+)
+
+var (
+	indexHTML = []byte("<!DOCTYPE html><html><head></head><body><div id=\"app\"></div></body></html>")
+	script    = []byte(strings.Repeat("console.log('fate');\n", 50))
+)
+
+func newTestHandler(t *testing.T) http.Handler {
+	h, err := newHandler(fstest.MapFS{
+		"index.html":                  {Data: indexHTML},
+		"assets/index-BkX3aP9q.js":    {Data: script},
+		"assets/index-BkX3aP9q.js.br": {Data: []byte("brotli")},
+		"img/icon.png":                {Data: []byte("\x89PNG\r\n\x1a\n")},
+	})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+	return h
+}
+
+func serve(h http.Handler, path string, header ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, path, nil)
+	for i := 0; i < len(header); i += 2 {
+		r.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler_index(t *testing.T) {
+	h := newTestHandler(t)
+
+	for _, path := range []string{"/", "/index.html", "/img/../index.html"} {
+		w := serve(h, path)
+		expect.WithMessage(t, path).That(
+			is.EqualTo(w.Code, http.StatusOK),
+			is.EqualTo(w.Header().Get("Cache-Control"), cacheRevalidate),
+			is.EqualTo(w.Header().Get("Content-Type"), "text/html; charset=utf-8"),
+			is.DeepEqualTo(w.Body.Bytes(), indexHTML),
+		)
+	}
+
+	w := serve(h, "/missing.js")
+	expect.That(t, is.EqualTo(w.Code, http.StatusNotFound))
+}
+
+func TestHandler_encoding(t *testing.T) {
+	h := newTestHandler(t)
+
+	w := serve(h, "/assets/index-BkX3aP9q.js", "Accept-Encoding", "gzip, deflate, br")
+	expect.That(t,
+		is.EqualTo(w.Code, http.StatusOK),
+		is.EqualTo(w.Header().Get("Content-Encoding"), "br"),
+		is.EqualTo(w.Header().Get("Vary"), "Accept-Encoding"),
+		is.EqualTo(w.Header().Get("Cache-Control"), cacheImmutable),
+		is.EqualTo(w.Body.String(), "brotli"),
+	)
+	brETag := w.Header().Get("ETag")
+
+	w = serve(h, "/assets/index-BkX3aP9q.js", "Accept-Encoding", "gzip, br;q=0")
+	expect.That(t, is.EqualTo(w.Header().Get("Content-Encoding"), "gzip"))
+	gzETag := w.Header().Get("ETag")
+
+	zr, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+	expect.That(t, expect.FailNow(is.NoError(err)))
+	got, err := io.ReadAll(zr)
+	expect.That(t, is.NoError(err), is.DeepEqualTo(got, script))
+
+	w = serve(h, "/assets/index-BkX3aP9q.js")
+	expect.That(t,
+		is.EqualTo(w.Header().Get("Content-Encoding"), ""),
+		is.DeepEqualTo(w.Body.Bytes(), script),
+	)
+	identityETag := w.Header().Get("ETag")
+
+	expect.That(t,
+		is.EqualTo(strings.HasPrefix(identityETag, `"`), true),
+		is.EqualTo(brETag != gzETag && gzETag != identityETag && brETag != identityETag, true),
+	)
+
+	w = serve(h, "/assets/index-BkX3aP9q.js.br")
+	expect.That(t, is.EqualTo(w.Code, http.StatusNotFound))
+
+	w = serve(h, "/img/icon.png", "Accept-Encoding", "gzip")
+	expect.That(t,
+		is.EqualTo(w.Header().Get("Content-Encoding"), ""),
+		is.EqualTo(w.Header().Get("Vary"), ""),
+	)
+}
+
+func TestHandler_conditional(t *testing.T) {
+	h := newTestHandler(t)
+
+	etag := serve(h, "/", "Accept-Encoding", "gzip").Header().Get("ETag")
+
+	w := serve(h, "/", "If-None-Match", etag)
+	expect.That(t, is.EqualTo(w.Code, http.StatusNotModified))
+
+	w = serve(h, "/assets/index-BkX3aP9q.js", "If-None-Match", etag)
+	expect.That(t, is.EqualTo(w.Code, http.StatusOK))
+}
+
+func TestAccepts(t *testing.T) {
+	tests := []struct {
+		header, encoding string
+		want             bool
+	}{
+		{"", "gzip", false},
+		{"gzip, deflate, br", "br", true},
+		{"gzip;q=0.5", "gzip", true},
+		{"GZIP", "gzip", true},
+		{"br;q=0, gzip", "br", false},
+		{"*", "br", true},
+		{"deflate", "gzip", false},
+	}
+
+	for _, test := range tests {
+		expect.WithMessage(t, "%q %s", test.header, test.encoding).That(
+			is.EqualTo(accepts(test.header, test.encoding), test.want),
+		)
+	}
+}
@@ -0,0 +1,177 @@
+// Package janitor implements a background process that expires sessions which have been idle for too long.
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/metrics"
+	"github.com/halimath/kvlog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	runsTotal = metrics.Default.NewCounter(prometheus.CounterOpts{
+		Name: "fate_table_janitor_runs_total",
+		Help: "Number of janitor runs",
+	})
+	expiredTotal = metrics.Default.NewCounterVec(prometheus.CounterOpts{
+		Name: "fate_table_sessions_expired_total",
+		Help: "Number of sessions expired by the janitor",
+	}, []string{"action"})
+)
+
+// Janitor expires idle sessions by either deleting or archiving them. Archived sessions are deleted once they
+// have not been modified for archiveTTL.
+type Janitor struct {
+	repo       usecase.SessionRepository
+	logger     kvlog.Logger
+	ttl        time.Duration
+	archiveTTL time.Duration
+	interval   time.Duration
+	action     string
+	now        func() time.Time
+	// heartbeat holds the time (in unix nanoseconds) the janitor has last been seen alive.
+	heartbeat atomic.Int64
+}
+
+// Provide creates a new Janitor working on repo configured from cfg.
+func Provide(cfg config.Config, logger kvlog.Logger, repo usecase.SessionRepository) *Janitor {
+	j := &Janitor{
+		repo:       repo,
+		logger:     logger,
+		ttl:        cfg.SessionIdleTTL,
+		archiveTTL: cfg.SessionArchiveTTL,
+		interval:   cfg.JanitorInterval,
+		action:     cfg.SessionExpiryAction,
+		now:        time.Now,
+	}
+	j.beat()
+
+	return j
+}
+
+// Check implements a health.Check reporting an error if the janitor has not completed a run for more than
+// twice its interval.
+func (j *Janitor) Check(context.Context) error {
+	if !j.enabled() {
+		return nil
+	}
+
+	if since := j.now().Sub(time.Unix(0, j.heartbeat.Load())); since > 2*j.interval {
+		return fmt.Errorf("janitor not alive for %s", since.Truncate(time.Second))
+	}
+
+	return nil
+}
+
+func (j *Janitor) enabled() bool {
+	return j.ttl > 0 && j.interval > 0
+}
+
+func (j *Janitor) beat() {
+	j.heartbeat.Store(j.now().UnixNano())
+}
+
+// Run periodically collects idle sessions until ctx is done. Run returns immediately if session expiry has
+// been disabled.
+func (j *Janitor) Run(ctx context.Context) {
+	if !j.enabled() {
+		j.logger.Logs("janitor disabled")
+		return
+	}
+
+	j.logger.Logs("janitor started", kvlog.WithKV("ttl", j.ttl), kvlog.WithKV("interval", j.interval),
+		kvlog.WithKV("action", j.action), kvlog.WithKV("archiveTTL", j.archiveTTL))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.beat()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Logs("janitor stopped")
+			return
+
+		case <-ticker.C:
+			if _, err := j.Collect(ctx); err != nil {
+				j.logger.Logs("janitor run failed", kvlog.WithErr(err))
+			}
+			j.beat()
+		}
+	}
+}
+
+// Collect performs a single run expiring all sessions not modified for longer than the configured TTL and
+// deleting archived sessions not modified for longer than the archive TTL. It returns the number of expired
+// sessions.
+func (j *Janitor) Collect(ctx context.Context) (int, error) {
+	start := time.Now()
+	runsTotal.Inc()
+
+	deadline := j.now().Add(-j.ttl)
+	archiveDeadline := j.now().Add(-j.archiveTTL)
+
+	// expiryAction returns the action to apply to s or an empty string if s has not expired.
+	expiryAction := func(s session.Session) string {
+		if j.action == config.SessionExpiryArchive && s.Archived {
+			if j.archiveTTL > 0 && s.LastModified.Before(archiveDeadline) {
+				return config.SessionExpiryDelete
+			}
+			return ""
+		}
+
+		if s.LastModified.Before(deadline) {
+			return j.action
+		}
+		return ""
+	}
+	idle := func(s session.Session) bool { return expiryAction(s) != "" }
+
+	candidates, err := j.repo.List(ctx, idle)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, c := range candidates {
+		if err := ctx.Err(); err != nil {
+			return expired, err
+		}
+
+		err := j.repo.Perform(ctx, c.ID, func(ctx context.Context, exists bool, s session.Session) (session.Session, error) {
+			// The session may have been modified since it has been listed.
+			action := expiryAction(s)
+			if !exists || action == "" {
+				return s, usecase.NoSave
+			}
+
+			expired++
+			expiredTotal.WithLabelValues(action).Inc()
+			j.logger.Logs("session expired", kvlog.WithKV("sessionID", s.ID), kvlog.WithKV("action", action),
+				kvlog.WithKV("lastModified", s.LastModified))
+
+			if action == config.SessionExpiryArchive {
+				s.Archived = true
+				return s, nil
+			}
+
+			return s, usecase.RemoveSession
+		})
+
+		if err != nil {
+			return expired, err
+		}
+	}
+
+	j.logger.Logs("janitor run", kvlog.WithKV("expired", expired), kvlog.WithKV("duration", time.Since(start)))
+
+	return expired, nil
+}
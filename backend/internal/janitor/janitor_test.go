@@ -0,0 +1,148 @@
+package janitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+	"github.com/halimath/fate-core-remote-table/backend/internal/repository"
+	"github.com/halimath/kvlog"
+)
+
+func setup(t *testing.T, action string) (*Janitor, usecase.SessionRepository) {
+	repo, err := repository.NewSessionRepository(config.Config{})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	for _, id := range []string{"1", "2"} {
+		err := repo.Perform(context.Background(), id, func(_ context.Context, _ bool, _ session.Session) (session.Session, error) {
+			return session.Session{ID: id, OwnerID: "3"}, nil
+		})
+		expect.That(t, is.NoError(err))
+	}
+
+	j := Provide(config.Config{
+		SessionIdleTTL:      time.Hour,
+		JanitorInterval:     time.Minute,
+		SessionExpiryAction: action,
+	}, kvlog.L, repo)
+
+	return j, repo
+}
+
+func listAll(t *testing.T, repo usecase.SessionRepository) []session.Session {
+	sessions, err := repo.List(context.Background(), func(session.Session) bool { return true })
+	expect.That(t, is.NoError(err))
+	return sessions
+}
+
+func TestJanitor_Collect(t *testing.T) {
+	t.Run("not_idle", func(t *testing.T) {
+		j, repo := setup(t, config.SessionExpiryDelete)
+
+		got, err := j.Collect(context.Background())
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(got, 0),
+			is.SliceOfLen(listAll(t, repo), 2),
+		)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		j, repo := setup(t, config.SessionExpiryDelete)
+		j.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+		got, err := j.Collect(context.Background())
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(got, 2),
+			is.SliceOfLen(listAll(t, repo), 0),
+		)
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		j, repo := setup(t, config.SessionExpiryArchive)
+		j.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+		got, err := j.Collect(context.Background())
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(got, 2),
+		)
+
+		sessions := listAll(t, repo)
+		expect.That(t, is.SliceOfLen(sessions, 2))
+		for _, s := range sessions {
+			expect.That(t, is.EqualTo(s.Archived, true))
+		}
+
+		got, err = j.Collect(context.Background())
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(got, 0),
+		)
+	})
+
+	t.Run("archive_ttl", func(t *testing.T) {
+		j, repo := setup(t, config.SessionExpiryArchive)
+		j.archiveTTL = 24 * time.Hour
+		j.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+		got, err := j.Collect(context.Background())
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(got, 2),
+			is.SliceOfLen(listAll(t, repo), 2),
+		)
+
+		j.now = func() time.Time { return time.Now().Add(25 * time.Hour) }
+
+		got, err = j.Collect(context.Background())
+		expect.That(t,
+			is.NoError(err),
+			is.EqualTo(got, 2),
+			is.SliceOfLen(listAll(t, repo), 0),
+		)
+	})
+}
+
+func TestJanitor_Run(t *testing.T) {
+	j, _ := setup(t, config.SessionExpiryDelete)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		j.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("janitor did not stop after context has been canceled")
+	}
+}
+
+func TestJanitor_Check(t *testing.T) {
+	j, _ := setup(t, config.SessionExpiryDelete)
+
+	now := time.Now()
+	j.now = func() time.Time { return now }
+	j.beat()
+
+	now = now.Add(2 * time.Minute)
+	expect.That(t, is.NoError(j.Check(context.Background())))
+
+	now = now.Add(time.Second)
+	expect.That(t, is.EqualTo(j.Check(context.Background()) != nil, true))
+
+	j.ttl = 0
+	expect.That(t, is.NoError(j.Check(context.Background())))
+}
@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+)
+
+// stream holds the append-only sequence of events recorded for a single session along with a snapshot of
+// the state produced by the first snapshotLen records.
+type stream struct {
+	lock sync.RWMutex
+	// ownerID caches the owner of the session which never changes once the session has been created. It may
+	// be read without holding lock.
+	ownerID string
+	records []session.Record
+	// removed is set when the session has been removed from the store while other units of work may still
+	// wait for the lock.
+	removed     bool
+	snapshot    session.Session
+	snapshotLen int
+	history     history
+}
+
+// count returns the number of records with a version less than or equal to version. The caller must hold
+// s's lock.
+func (s *stream) count(version uint64) int {
+	n, _ := slices.BinarySearchFunc(s.records, version+1, func(r session.Record, v uint64) int {
+		return cmp.Compare(r.Version, v)
+	})
+	return n
+}
+
+// state rebuilds the session's current state by replaying all records after the snapshot. The caller must
+// hold s's lock.
+func (s *stream) state() session.Session {
+	return s.replay(len(s.records))
+}
+
+// replay rebuilds the session's state produced by the first n records. The caller must hold s's lock.
+func (s *stream) replay(n int) session.Session {
+	var ses session.Session
+	from := 0
+
+	if n >= s.snapshotLen {
+		ses = s.snapshot.Clone()
+		from = s.snapshotLen
+	}
+
+	for _, r := range s.records[from:n] {
+		ses.Apply(r.Event)
+	}
+
+	if n > 0 {
+		ses.Version = s.records[n-1].Version
+		ses.LastModified = s.records[n-1].Time
+	}
+
+	return ses
+}
+
+// EventStore implements a usecase.SessionRepository which stores each session as an append-only stream of
+// domain events. A session's state is rebuilt by replaying its events starting from the latest snapshot.
+// EventStore implements usecase.SessionHistory to expose the events and past states of a session.
+type EventStore struct {
+	lock             sync.RWMutex
+	streams          map[string]*stream
+	snapshotInterval int
+	historySize      int
+}
+
+// NewEventStore creates a new, empty EventStore.
+func NewEventStore(cfg config.Config) *EventStore {
+	return &EventStore{
+		streams:          make(map[string]*stream),
+		snapshotInterval: cfg.EventSnapshotInterval,
+		historySize:      cfg.UndoHistorySize,
+	}
+}
+
+func (r *EventStore) Perform(ctx context.Context, id string, uow usecase.UnitOfWork) error {
+	ctx, span := startPerform(ctx, id)
+	defer span.End()
+
+	r.lock.RLock()
+	s, ok := r.streams[id]
+	r.lock.RUnlock()
+
+	var current session.Session
+
+	if ok {
+		lock(ctx, &s.lock)
+		defer s.lock.Unlock()
+
+		// Give up if the caller's deadline expired while waiting for the lock.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if s.removed {
+			ok = false
+			s = nil
+		} else {
+			current = s.state()
+		}
+	}
+
+	// Pass a copy to uow so that in-place modifications don't alter current.
+	newSession, err := uow(ctx, ok, current.Clone())
+
+	if err == usecase.NoSave {
+		if s != nil {
+			usecase.RecordVersion(ctx, current.Version)
+		}
+		return nil
+	}
+
+	if err == usecase.RemoveSession {
+		if s != nil {
+			r.lock.Lock()
+			delete(r.streams, id)
+			r.lock.Unlock()
+			s.removed = true
+		}
+		return nil
+	}
+
+	actor, _ := auth.UserID(ctx)
+
+	if err == usecase.Undo || err == usecase.Redo {
+		if s == nil {
+			return usecase.ErrNotFound
+		}
+
+		if err == usecase.Undo {
+			newSession, err = s.history.revert(actor, current)
+		} else {
+			newSession, err = s.history.reapply(actor, current)
+		}
+		if err != nil {
+			return err
+		}
+
+		r.append(ctx, s, current, actor, session.Changes(current, newSession))
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if s == nil {
+		s = &stream{ownerID: newSession.OwnerID}
+		r.lock.Lock()
+		r.streams[newSession.ID] = s
+		r.lock.Unlock()
+
+		r.append(ctx, s, current, actor, []session.Event{session.SessionCreated{Session: newSession.Clone()}})
+		return nil
+	}
+
+	events := session.Changes(current, newSession)
+	if len(events) == 0 {
+		usecase.RecordVersion(ctx, current.Version)
+		return nil
+	}
+
+	s.history.record(actor, current, r.historySize)
+	r.append(ctx, s, current, actor, events)
+
+	return nil
+}
+
+// append appends events to s as a new version of the session and takes a snapshot when the number of records
+// since the last snapshot reaches the snapshot interval. The resulting version is reported using
+// usecase.RecordVersion. The caller must hold s's lock.
+func (r *EventStore) append(ctx context.Context, s *stream, current session.Session, actor string, events []session.Event) {
+	if len(events) == 0 {
+		usecase.RecordVersion(ctx, current.Version)
+		return
+	}
+
+	version := current.Version + 1
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	for _, e := range events {
+		s.records = append(s.records, session.Record{
+			Version: version,
+			Time:    now,
+			Actor:   actor,
+			Event:   e,
+		})
+	}
+
+	if r.snapshotInterval > 0 && len(s.records)-s.snapshotLen >= r.snapshotInterval {
+		s.snapshot = s.state()
+		s.snapshotLen = len(s.records)
+	}
+
+	usecase.RecordVersion(ctx, version)
+}
+
+func (r *EventStore) List(ctx context.Context, filter func(s session.Session) bool) ([]session.Session, error) {
+	r.lock.RLock()
+	streams := make([]*stream, 0, len(r.streams))
+	for _, s := range r.streams {
+		streams = append(streams, s)
+	}
+	r.lock.RUnlock()
+
+	sessions := make([]session.Session, 0)
+	for _, s := range streams {
+		s.lock.RLock()
+		removed := s.removed
+		var ses session.Session
+		if !removed {
+			ses = s.state()
+		}
+		s.lock.RUnlock()
+
+		if !removed && filter(ses) {
+			sessions = append(sessions, ses)
+		}
+	}
+
+	slices.SortFunc(sessions, func(a, b session.Session) int {
+		return b.LastModified.Compare(a.LastModified)
+	})
+
+	return sessions, nil
+}
+
+func (r *EventStore) CountByOwner(ctx context.Context, ownerID string) (int, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	n := 0
+	for _, s := range r.streams {
+		if s.ownerID == ownerID {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+func (r *EventStore) Len() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return len(r.streams)
+}
+
+// Ping verifies that the store's index can be locked.
+func (r *EventStore) Ping(ctx context.Context) error {
+	r.lock.RLock()
+	r.lock.RUnlock()
+
+	return ctx.Err()
+}
+
+// Events returns the records of the session identified by id with a version greater than since. It returns
+// usecase.ErrNotFound if no such session exists.
+func (r *EventStore) Events(ctx context.Context, id string, since uint64) ([]session.Record, error) {
+	s, err := r.stream(id)
+	if err != nil {
+		return nil, err
+	}
+	defer s.lock.RUnlock()
+
+	return slices.Clone(s.records[s.count(since):]), nil
+}
+
+// At returns the state of the session identified by id at the given version. It returns usecase.ErrNotFound
+// if no such session or version exists.
+func (r *EventStore) At(ctx context.Context, id string, version uint64) (session.Session, error) {
+	s, err := r.stream(id)
+	if err != nil {
+		return session.Session{}, err
+	}
+	defer s.lock.RUnlock()
+
+	n := s.count(version)
+	if n == 0 || s.records[n-1].Version != version {
+		return session.Session{}, usecase.ErrNotFound
+	}
+
+	return s.replay(n), nil
+}
+
+// stream returns the read-locked stream for id.
+func (r *EventStore) stream(id string) (*stream, error) {
+	r.lock.RLock()
+	s, ok := r.streams[id]
+	r.lock.RUnlock()
+
+	if !ok {
+		return nil, usecase.ErrNotFound
+	}
+
+	s.lock.RLock()
+	if s.removed {
+		s.lock.RUnlock()
+		return nil, usecase.ErrNotFound
+	}
+
+	return s, nil
+}
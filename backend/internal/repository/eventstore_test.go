@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+)
+
+func TestEventStore(t *testing.T) {
+	repo := NewEventStore(config.Config{EventSnapshotInterval: 2, UndoHistorySize: 5})
+	ctx := auth.WithUserID(context.Background(), "owner")
+
+	perform := func(f func(s *session.Session)) {
+		err := repo.Perform(ctx, "1", func(_ context.Context, exists bool, s session.Session) (session.Session, error) {
+			if !exists {
+				s = session.New("1", "owner", "test")
+			}
+			f(&s)
+			return s, nil
+		})
+		expect.That(t, is.NoError(err))
+	}
+
+	load := func() (s session.Session) {
+		err := repo.Perform(ctx, "1", func(_ context.Context, exists bool, ses session.Session) (session.Session, error) {
+			expect.That(t, is.EqualTo(exists, true))
+			s = ses
+			return ses, usecase.NoSave
+		})
+		expect.That(t, is.NoError(err))
+		return
+	}
+
+	perform(func(s *session.Session) {})
+
+	var recorded uint64
+	err := repo.Perform(usecase.WithVersionRecorder(ctx, &recorded), "1", func(_ context.Context, _ bool, s session.Session) (session.Session, error) {
+		s.AddAspect("Dark night")
+		return s, nil
+	})
+	expect.That(t, is.NoError(err), is.EqualTo(recorded, uint64(2)))
+	perform(func(s *session.Session) {
+		c := s.AddCharacter("player", session.PC, "Hero")
+		c.FatePoints = 3
+	})
+	perform(func(s *session.Session) { s.Characters[0].FatePoints-- })
+	perform(func(s *session.Session) {})
+
+	owned, err := repo.CountByOwner(ctx, "owner")
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(owned, 1),
+	)
+
+	got := load()
+	expect.That(t,
+		is.EqualTo(got.Version, uint64(4)),
+		is.SliceOfLen(got.Aspects, 1),
+		is.SliceOfLen(got.Characters, 1),
+		is.EqualTo(got.Characters[0].FatePoints, 2),
+		is.EqualTo(repo.streams["1"].snapshotLen, 4),
+	)
+
+	records, err := repo.Events(ctx, "1", 2)
+	expect.That(t,
+		is.NoError(err),
+		is.SliceOfLen(records, 2),
+		is.EqualTo(records[0].Event.Type(), "CharacterJoined"),
+		is.EqualTo(records[1].Event.Type(), "FatePointsChanged"),
+		is.EqualTo(records[1].Version, uint64(4)),
+		is.EqualTo(records[1].Actor, "owner"),
+	)
+
+	old, err := repo.At(ctx, "1", 3)
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(old.Version, uint64(3)),
+		is.EqualTo(old.Characters[0].FatePoints, 3),
+	)
+
+	_, err = repo.At(ctx, "1", 5)
+	expect.That(t, is.Error(err, usecase.ErrNotFound))
+
+	err = repo.Perform(ctx, "1", func(_ context.Context, _ bool, s session.Session) (session.Session, error) {
+		return s, usecase.Undo
+	})
+	expect.That(t, is.NoError(err))
+
+	got = load()
+	expect.That(t,
+		is.EqualTo(got.Version, uint64(5)),
+		is.EqualTo(got.Characters[0].FatePoints, 3),
+	)
+
+	err = repo.Perform(ctx, "1", func(_ context.Context, _ bool, s session.Session) (session.Session, error) {
+		return s, usecase.RemoveSession
+	})
+	expect.That(t, is.NoError(err))
+
+	_, err = repo.Events(ctx, "1", 0)
+	expect.That(t, is.Error(err, usecase.ErrNotFound))
+
+	sessions, err := repo.List(ctx, func(session.Session) bool { return true })
+	expect.That(t,
+		is.NoError(err),
+		is.SliceOfLen(sessions, 0),
+	)
+
+	owned, err = repo.CountByOwner(ctx, "owner")
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(owned, 0),
+	)
+}
+
+func TestNewSessionRepository_events(t *testing.T) {
+	repo, err := NewSessionRepository(config.Config{DevMode: true, SessionStore: config.SessionStoreEvents})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	sessions, err := repo.List(context.Background(), func(session.Session) bool { return true })
+	expect.That(t,
+		is.NoError(err),
+		is.SliceOfLen(sessions, 1),
+		is.EqualTo(sessions[0].Title, "Test data"),
+		is.EqualTo(sessions[0].Version, uint64(1)),
+	)
+}
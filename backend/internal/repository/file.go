@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+)
+
+// FileStore implements a usecase.SessionRepository which keeps sessions in memory and persists them to a
+// JSON file. Sessions are read from the file when the store is opened and the file is rewritten after each
+// unit of work modifying a session. While open, the store holds an exclusive lock on a lock file next to the
+// session file so that no other process modifies the file concurrently.
+type FileStore struct {
+	*repository
+	path string
+	lock *os.File
+	// writeLock serializes writing the file.
+	writeLock sync.Mutex
+}
+
+// NewFileStore opens the FileStore persisting sessions to the file named by cfg.SessionFile. A missing file
+// is treated as an empty store. NewFileStore fails if the file is held by another FileStore. Close releases
+// the file.
+func NewFileStore(cfg config.Config) (*FileStore, error) {
+	s := &FileStore{
+		repository: newRepository(cfg),
+		path:       cfg.SessionFile,
+	}
+
+	lock, err := lockFile(s.path + ".lock")
+	if err != nil {
+		return nil, fmt.Errorf("session file %s is in use by another process (is the service running?): %w", s.path, err)
+	}
+	s.lock = lock
+
+	if err := s.load(); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read session file: %w", err)
+	}
+
+	var sessions []session.Session
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return fmt.Errorf("failed to parse session file %s: %w", s.path, err)
+	}
+
+	for _, ses := range sessions {
+		s.store[ses.ID] = &sessionAndLock{ownerID: ses.OwnerID, s: ses}
+	}
+
+	return nil
+}
+
+// Perform performs uow and writes all sessions to the store's file if uow modified a session. The
+// modification stays in effect even if writing the file fails; it is written with the next successful write.
+func (s *FileStore) Perform(ctx context.Context, id string, uow usecase.UnitOfWork) error {
+	modified := false
+	err := s.repository.Perform(ctx, id, func(ctx context.Context, exists bool, ses session.Session) (session.Session, error) {
+		ses, err := uow(ctx, exists, ses)
+		modified = err == nil || err == usecase.RemoveSession || err == usecase.Undo || err == usecase.Redo
+		return ses, err
+	})
+	if err != nil || !modified {
+		return err
+	}
+
+	return s.Flush(ctx)
+}
+
+// Ping verifies that the store's index can be locked and the directory containing the store's file is
+// accessible.
+func (s *FileStore) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		return err
+	}
+
+	_, err := os.Stat(filepath.Dir(s.path))
+	return err
+}
+
+// Close releases the lock on the store's file. The store must not be used after Close has been called.
+func (s *FileStore) Close() error {
+	return unlockFile(s.lock)
+}
+
+// Flush writes all sessions to the store's file. The file is replaced atomically so that a failed flush
+// leaves the previous contents intact.
+func (s *FileStore) Flush(ctx context.Context) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	sessions, err := s.List(ctx, func(session.Session) bool { return true })
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+)
+
+func TestFileStore(t *testing.T) {
+	cfg := config.Config{SessionStore: config.SessionStoreFile, SessionFile: filepath.Join(t.TempDir(), "sessions.json")}
+
+	store, err := NewFileStore(cfg)
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	want := session.Session{ID: "1", OwnerID: "2", Title: "Test"}
+	err = store.Perform(context.Background(), want.ID, func(context.Context, bool, session.Session) (session.Session, error) {
+		return want, nil
+	})
+	expect.That(t, is.NoError(err))
+	expect.That(t, is.NoError(store.Close()))
+
+	reopened, err := NewFileStore(cfg)
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	sessions, err := reopened.List(context.Background(), func(session.Session) bool { return true })
+	expect.That(t,
+		is.NoError(err),
+		is.SliceOfLen(sessions, 1),
+		is.DeepEqualTo(sessions[0], want, is.ExcludeFields{"LastModified", "Version"}),
+		is.EqualTo(sessions[0].Version, uint64(1)),
+	)
+
+	owned, err := reopened.CountByOwner(context.Background(), "2")
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(owned, 1),
+	)
+}
+
+func TestFileStore_locked(t *testing.T) {
+	cfg := config.Config{SessionStore: config.SessionStoreFile, SessionFile: filepath.Join(t.TempDir(), "sessions.json")}
+
+	store, err := NewFileStore(cfg)
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	_, err = NewFileStore(cfg)
+	expect.That(t, is.EqualTo(err != nil, true))
+
+	expect.That(t, is.NoError(store.Close()))
+
+	store, err = NewFileStore(cfg)
+	expect.That(t, expect.FailNow(is.NoError(err)))
+	expect.That(t, is.NoError(store.Close()))
+}
+
+func TestFileStore_invalidFile(t *testing.T) {
+	cfg := config.Config{SessionStore: config.SessionStoreFile, SessionFile: filepath.Join(t.TempDir(), "sessions.json")}
+	expect.That(t, expect.FailNow(is.NoError(os.WriteFile(cfg.SessionFile, []byte("{"), 0o600))))
+
+	_, err := NewSessionRepository(cfg)
+	expect.That(t, is.EqualTo(err != nil, true))
+}
@@ -0,0 +1,24 @@
+//go:build !unix
+
+package repository
+
+import (
+	"errors"
+	"os"
+)
+
+// lockFile creates the file named name failing if it already exists. Unlike on unix systems the file remains
+// if the process exits without calling unlockFile and must then be removed manually.
+func lockFile(name string) (*os.File, error) {
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return nil, errors.New("lock file " + name + " exists")
+	}
+	return f, err
+}
+
+// unlockFile releases the lock acquired by lockFile by removing the file.
+func unlockFile(f *os.File) error {
+	f.Close()
+	return os.Remove(f.Name())
+}
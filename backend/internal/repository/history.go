@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+)
+
+// history implements a bounded undo/redo history of a single session. It records the states of the session
+// before each change made by a single actor. Changes made by any other actor clear the history because
+// restoring a recorded state would silently revert them.
+type history struct {
+	actor string
+	undo  []session.Session
+	redo  []session.Session
+}
+
+// record records before as the state of the session prior to a change made by actor keeping at most size
+// states.
+func (h *history) record(actor string, before session.Session, size int) {
+	if size <= 0 {
+		return
+	}
+
+	if actor != h.actor {
+		h.actor = actor
+		h.undo = nil
+	}
+	h.redo = nil
+
+	if len(h.undo) >= size {
+		h.undo = h.undo[len(h.undo)-size+1:]
+	}
+	h.undo = append(h.undo, before)
+}
+
+// revert returns the state to restore when actor undoes the last change to current.
+func (h *history) revert(actor string, current session.Session) (session.Session, error) {
+	if actor != h.actor || len(h.undo) == 0 {
+		return current, usecase.ErrNothingToUndo
+	}
+
+	s := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, current)
+
+	return s, nil
+}
+
+// reapply returns the state to restore when actor redoes the last undone change to current.
+func (h *history) reapply(actor string, current session.Session) (session.Session, error) {
+	if actor != h.actor || len(h.redo) == 0 {
+		return current, usecase.ErrNothingToRedo
+	}
+
+	s := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, current)
+
+	return s, nil
+}
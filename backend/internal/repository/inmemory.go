@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
 	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
 	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
 	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
@@ -12,30 +14,90 @@ import (
 
 type sessionAndLock struct {
 	lock sync.RWMutex
-	s    session.Session
+	// ownerID caches the owner of s which never changes once the session has been created. It may be read
+	// without holding lock.
+	ownerID string
+	s       session.Session
+	// removed is set when the session has been removed from the store while other units of work may still
+	// wait for the lock.
+	removed bool
+	history history
 }
 
 type repository struct {
-	lock  sync.RWMutex
-	store map[string]*sessionAndLock
+	lock        sync.RWMutex
+	store       map[string]*sessionAndLock
+	historySize int
 }
 
 func (r *repository) Perform(ctx context.Context, id string, uow usecase.UnitOfWork) error {
+	ctx, span := startPerform(ctx, id)
+	defer span.End()
+
 	r.lock.RLock()
 	s, ok := r.store[id]
 	r.lock.RUnlock()
 
+	if ok {
+		lock(ctx, &s.lock)
+		defer s.lock.Unlock()
+
+		// Give up if the caller's deadline expired while waiting for the lock.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if s.removed {
+			ok = false
+			s = nil
+		}
+	}
+
 	var newSession session.Session
 	var err error
 	if ok {
-		s.lock.Lock()
-		defer s.lock.Unlock()
-		newSession, err = uow(ctx, ok, s.s)
+		// Pass a deep copy so that changes made by uow do not alias the stored state which is recorded in the
+		// undo history below.
+		newSession, err = uow(ctx, ok, s.s.Clone())
 	} else {
 		newSession, err = uow(ctx, ok, session.Session{})
 	}
 
 	if err == usecase.NoSave {
+		if s != nil {
+			usecase.RecordVersion(ctx, s.s.Version)
+		}
+		return nil
+	}
+
+	if err == usecase.RemoveSession {
+		if s != nil {
+			r.lock.Lock()
+			delete(r.store, id)
+			r.lock.Unlock()
+			s.removed = true
+		}
+		return nil
+	}
+
+	actor, _ := auth.UserID(ctx)
+
+	if err == usecase.Undo || err == usecase.Redo {
+		if s == nil {
+			return usecase.ErrNotFound
+		}
+
+		if err == usecase.Undo {
+			newSession, err = s.history.revert(actor, s.s)
+		} else {
+			newSession, err = s.history.reapply(actor, s.s)
+		}
+		if err != nil {
+			return err
+		}
+
+		s.save(newSession)
+		usecase.RecordVersion(ctx, s.s.Version)
 		return nil
 	}
 
@@ -44,39 +106,123 @@ func (r *repository) Perform(ctx context.Context, id string, uow usecase.UnitOfW
 	}
 
 	if s == nil {
-		s = &sessionAndLock{}
+		s = &sessionAndLock{ownerID: newSession.OwnerID}
 		r.lock.Lock()
 		r.store[newSession.ID] = s
 		r.lock.Unlock()
+	} else {
+		s.history.record(actor, s.s, r.historySize)
 	}
 
+	s.save(newSession)
+	usecase.RecordVersion(ctx, s.s.Version)
+
+	return nil
+}
+
+// save replaces the stored session with newSession incrementing its version. The caller must hold s's lock.
+func (s *sessionAndLock) save(newSession session.Session) {
+	version := s.s.Version
 	s.s = newSession
+	s.s.Version = version + 1
 	s.s.LastModified = time.Now().UTC().Truncate(time.Millisecond)
+}
 
-	return nil
+func (r *repository) List(ctx context.Context, filter func(s session.Session) bool) ([]session.Session, error) {
+	r.lock.RLock()
+	entries := make([]*sessionAndLock, 0, len(r.store))
+	for _, s := range r.store {
+		entries = append(entries, s)
+	}
+	r.lock.RUnlock()
+
+	sessions := make([]session.Session, 0)
+	for _, e := range entries {
+		e.lock.RLock()
+		s, removed := e.s, e.removed
+		e.lock.RUnlock()
+
+		if !removed && filter(s) {
+			sessions = append(sessions, s)
+		}
+	}
+
+	slices.SortFunc(sessions, func(a, b session.Session) int {
+		return b.LastModified.Compare(a.LastModified)
+	})
+
+	return sessions, nil
 }
 
-func NewSessionRepository(cfg config.Config) usecase.SessionRepository {
-	r := &repository{
-		store: make(map[string]*sessionAndLock),
+func (r *repository) CountByOwner(ctx context.Context, ownerID string) (int, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	n := 0
+	for _, s := range r.store {
+		if s.ownerID == ownerID {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+func (r *repository) Len() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return len(r.store)
+}
+
+// Ping verifies that the store's index can be locked.
+func (r *repository) Ping(ctx context.Context) error {
+	r.lock.RLock()
+	r.lock.RUnlock()
+
+	return ctx.Err()
+}
+
+// NewSessionRepository creates the session repository selected by cfg.SessionStore.
+func NewSessionRepository(cfg config.Config) (Store, error) {
+	var r Store
+
+	switch cfg.SessionStore {
+	case config.SessionStoreEvents:
+		r = NewEventStore(cfg)
+	case config.SessionStoreFile:
+		s, err := NewFileStore(cfg)
+		if err != nil {
+			return nil, err
+		}
+		r = s
+	default:
+		r = newRepository(cfg)
 	}
 
 	if cfg.DevMode {
 		generateTestData(r)
 	}
 
-	return r
+	return r, nil
+}
+
+func newRepository(cfg config.Config) *repository {
+	return &repository{
+		store:       make(map[string]*sessionAndLock),
+		historySize: cfg.UndoHistorySize,
+	}
 }
 
-func generateTestData(r *repository) {
+func generateTestData(r usecase.SessionRepository) {
 	i := "3fa85f64-5717-4562-b3fc-2c963f66afa6"
 	owner := "00000000-0000-0000-0000-000000000000"
 
-	r.store[i] = &sessionAndLock{
-		s: session.Session{
-			ID:      i,
-			OwnerID: owner,
-			Title:   "Test data",
-		},
-	}
+	r.Perform(context.Background(), i, func(_ context.Context, exists bool, s session.Session) (session.Session, error) {
+		// Keep the test data persisted by a durable store.
+		if exists {
+			return s, usecase.NoSave
+		}
+		return session.New(i, owner, "Test data"), nil
+	})
 }
@@ -8,13 +8,15 @@ import (
 
 	"github.com/halimath/expect"
 	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
 	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
 	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
 	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
 )
 
 func TestInMemory(t *testing.T) {
-	repo := NewSessionRepository(config.Config{DevMode: true})
+	repo, err := NewSessionRepository(config.Config{DevMode: true})
+	expect.That(t, expect.FailNow(is.NoError(err)))
 
 	want := session.Session{
 		ID:      "1",
@@ -22,7 +24,7 @@ func TestInMemory(t *testing.T) {
 		Title:   "Test",
 	}
 
-	err := repo.Perform(context.Background(), want.ID, func(_ context.Context, exists bool, s session.Session) (session.Session, error) {
+	err = repo.Perform(context.Background(), want.ID, func(_ context.Context, exists bool, s session.Session) (session.Session, error) {
 		expect.That(t, is.EqualTo(exists, false))
 		return want, nil
 	})
@@ -33,8 +35,9 @@ func TestInMemory(t *testing.T) {
 	err = repo.Perform(context.Background(), want.ID, func(_ context.Context, exists bool, s session.Session) (session.Session, error) {
 		expect.That(t,
 			is.EqualTo(exists, true),
-			is.DeepEqualTo(s, want, is.ExcludeFields{"LastModified"}),
+			is.DeepEqualTo(s, want, is.ExcludeFields{"LastModified", "Version"}),
 			is.EqualTo(s.LastModified.After(want.LastModified), true),
+			is.EqualTo(s.Version, uint64(1)),
 		)
 		lastModified = s.LastModified
 		return want, usecase.NoSave
@@ -54,3 +57,201 @@ func TestInMemory(t *testing.T) {
 	expect.That(t, is.Error(err, wantErr))
 
 }
+
+func TestInMemory_List(t *testing.T) {
+	repo, err := NewSessionRepository(config.Config{})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	for _, s := range []session.Session{{ID: "1", OwnerID: "2"}, {ID: "3", OwnerID: "4"}, {ID: "5", OwnerID: "2"}} {
+		err := repo.Perform(context.Background(), s.ID, func(_ context.Context, _ bool, _ session.Session) (session.Session, error) {
+			return s, nil
+		})
+		expect.That(t, is.NoError(err))
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	got, err := repo.List(context.Background(), func(s session.Session) bool { return s.OwnerID == "2" })
+	expect.That(t,
+		is.NoError(err),
+		is.SliceOfLen(got, 2),
+	)
+	expect.That(t,
+		is.EqualTo(got[0].ID, "5"),
+		is.EqualTo(got[1].ID, "1"),
+	)
+
+	n, err := repo.CountByOwner(context.Background(), "2")
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(n, 2),
+	)
+}
+
+func TestInMemory_recordsVersion(t *testing.T) {
+	repo, err := NewSessionRepository(config.Config{})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	for _, want := range []uint64{1, 2} {
+		var got uint64
+		ctx := usecase.WithVersionRecorder(context.Background(), &got)
+		err := repo.Perform(ctx, "1", func(_ context.Context, _ bool, s session.Session) (session.Session, error) {
+			s.ID = "1"
+			return s, nil
+		})
+		expect.That(t, is.NoError(err), is.EqualTo(got, want))
+	}
+
+	var got uint64
+	ctx := usecase.WithVersionRecorder(context.Background(), &got)
+	err = repo.Perform(ctx, "1", func(_ context.Context, _ bool, s session.Session) (session.Session, error) {
+		return s, usecase.NoSave
+	})
+	expect.That(t, is.NoError(err), is.EqualTo(got, uint64(2)))
+}
+
+func TestInMemory_RemoveSession(t *testing.T) {
+	repo, err := NewSessionRepository(config.Config{})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	err = repo.Perform(context.Background(), "1", func(_ context.Context, _ bool, _ session.Session) (session.Session, error) {
+		return session.Session{ID: "1", OwnerID: "2"}, nil
+	})
+	expect.That(t, is.NoError(err))
+
+	err = repo.Perform(context.Background(), "1", func(_ context.Context, exists bool, s session.Session) (session.Session, error) {
+		expect.That(t, is.EqualTo(exists, true))
+		return s, usecase.RemoveSession
+	})
+	expect.That(t, is.NoError(err))
+
+	err = repo.Perform(context.Background(), "1", func(_ context.Context, exists bool, s session.Session) (session.Session, error) {
+		expect.That(t, is.EqualTo(exists, false))
+		return s, usecase.NoSave
+	})
+	expect.That(t, is.NoError(err))
+
+	got, err := repo.List(context.Background(), func(session.Session) bool { return true })
+	expect.That(t,
+		is.NoError(err),
+		is.SliceOfLen(got, 0),
+	)
+
+	n, err := repo.CountByOwner(context.Background(), "2")
+	expect.That(t,
+		is.NoError(err),
+		is.EqualTo(n, 0),
+	)
+}
+
+func TestInMemory_undoRedo(t *testing.T) {
+	repo, err := NewSessionRepository(config.Config{UndoHistorySize: 2})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+	gm := auth.WithUserID(context.Background(), "gm")
+	player := auth.WithUserID(context.Background(), "player")
+
+	setTitle := func(ctx context.Context, title string) {
+		err := repo.Perform(ctx, "1", func(_ context.Context, _ bool, s session.Session) (session.Session, error) {
+			s.ID = "1"
+			s.Title = title
+			return s, nil
+		})
+		expect.That(t, is.NoError(err))
+	}
+
+	history := func(ctx context.Context, op error) error {
+		return repo.Perform(ctx, "1", func(_ context.Context, _ bool, s session.Session) (session.Session, error) {
+			return s, op
+		})
+	}
+
+	title := func() (title string, version uint64) {
+		repo.Perform(context.Background(), "1", func(_ context.Context, _ bool, s session.Session) (session.Session, error) {
+			title, version = s.Title, s.Version
+			return s, usecase.NoSave
+		})
+		return
+	}
+
+	setTitle(gm, "a")
+	setTitle(gm, "b")
+	setTitle(gm, "c")
+	setTitle(gm, "d")
+
+	expect.That(t, is.NoError(history(gm, usecase.Undo)))
+	expect.That(t, is.NoError(history(gm, usecase.Undo)))
+	expect.That(t, is.Error(history(gm, usecase.Undo), usecase.ErrNothingToUndo))
+
+	got, version := title()
+	expect.That(t,
+		is.EqualTo(got, "b"),
+		is.EqualTo(version, uint64(6)),
+	)
+
+	expect.That(t, is.Error(history(player, usecase.Redo), usecase.ErrNothingToRedo))
+	expect.That(t, is.NoError(history(gm, usecase.Redo)))
+
+	got, _ = title()
+	expect.That(t, is.EqualTo(got, "c"))
+
+	setTitle(player, "e")
+
+	expect.That(t,
+		is.Error(history(gm, usecase.Undo), usecase.ErrNothingToUndo),
+		is.Error(history(gm, usecase.Redo), usecase.ErrNothingToRedo),
+	)
+}
+
+func TestInMemory_undoRestoresUnaliasedState(t *testing.T) {
+	repo, err := NewSessionRepository(config.Config{UndoHistorySize: 5})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+	gm := auth.WithUserID(context.Background(), "gm")
+
+	var aspectID, characterID string
+	err = repo.Perform(gm, "1", func(_ context.Context, _ bool, _ session.Session) (session.Session, error) {
+		s := session.New("1", "gm", "Test")
+		aspectID = s.AddAspect("a").ID
+		s.AddAspect("b")
+		s.AddAspect("c")
+		c := s.AddCharacter("player", session.PC, "Hero")
+		c.FatePoints = 3
+		characterID = c.ID
+		return s, nil
+	})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+
+	load := func() (s session.Session) {
+		repo.Perform(context.Background(), "1", func(_ context.Context, _ bool, current session.Session) (session.Session, error) {
+			s = current
+			return current, usecase.NoSave
+		})
+		return
+	}
+
+	fatePoints := func(s session.Session) int {
+		return s.FindCharacter(characterID).FatePoints
+	}
+
+	aspectNames := func(s session.Session) []string {
+		names := make([]string, 0, len(s.Aspects))
+		for _, a := range s.Aspects {
+			names = append(names, a.Name)
+		}
+		return names
+	}
+
+	undo := usecase.ProvideUndoSession(repo)
+
+	err = usecase.ProvideDeleteAspect(repo)(gm, usecase.DeleteAspectRequest{SessionID: "1", AspectID: aspectID})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+	expect.That(t, is.DeepEqualTo(aspectNames(load()), []string{"b", "c"}))
+
+	expect.That(t, expect.FailNow(is.NoError(undo(gm, "1"))))
+	expect.That(t, is.DeepEqualTo(aspectNames(load()), []string{"a", "b", "c"}))
+
+	err = usecase.ProvideUpdateFatePoints(repo)(gm, usecase.UpdateFatePointsRequest{SessionID: "1", CharacterID: characterID, Delta: 1})
+	expect.That(t, expect.FailNow(is.NoError(err)))
+	expect.That(t, is.EqualTo(fatePoints(load()), 4))
+
+	expect.That(t, expect.FailNow(is.NoError(undo(gm, "1"))))
+	expect.That(t, is.EqualTo(fatePoints(load()), 3))
+}
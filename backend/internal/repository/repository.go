@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/health"
+)
+
+// Store is implemented by all session repositories provided by this package.
+type Store interface {
+	usecase.SessionRepository
+	// Len returns the number of sessions stored.
+	Len() int
+	// Ping verifies that the store is accessible without loading any sessions.
+	Ping(ctx context.Context) error
+}
+
+// Flusher is implemented by repositories which buffer writes to durable storage. Flush writes all buffered
+// data and is called before the service exits.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// SizeGauge returns a function reporting the number of sessions stored in s for use with
+// promauto.Factory.NewGaugeFunc.
+func SizeGauge(s Store) func() float64 {
+	return func() float64 {
+		return float64(s.Len())
+	}
+}
+
+// ReachabilityCheck returns a health.Check verifying that s is accessible.
+func ReachabilityCheck(s Store) health.Check {
+	return s.Ping
+}
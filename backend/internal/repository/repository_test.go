@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+)
+
+func TestSizeGaugeAndReachabilityCheck(t *testing.T) {
+	for _, store := range []string{config.SessionStoreMemory, config.SessionStoreEvents} {
+		repo, err := NewSessionRepository(config.Config{SessionStore: store})
+		expect.That(t, expect.FailNow(is.NoError(err)))
+
+		for _, id := range []string{"1", "2"} {
+			err := repo.Perform(context.Background(), id, func(context.Context, bool, session.Session) (session.Session, error) {
+				return session.Session{ID: id, OwnerID: "3"}, nil
+			})
+			expect.That(t, is.NoError(err))
+		}
+
+		expect.WithMessage(t, store).That(
+			is.EqualTo(SizeGauge(repo)(), 2.0),
+			is.NoError(ReachabilityCheck(repo)(context.Background())),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		expect.WithMessage(t, store).That(is.Error(ReachabilityCheck(repo)(ctx), context.Canceled))
+	}
+}
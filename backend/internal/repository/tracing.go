@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// lockWaitKey is the span attribute holding the time in seconds a unit of work waited for the session's
+// lock.
+const lockWaitKey = attribute.Key("session.lock_wait_seconds")
+
+// startPerform starts the span covering a call to Perform for the session identified by id.
+func startPerform(ctx context.Context, id string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{tracing.SessionID.String(id)}
+	if userID, ok := auth.UserID(ctx); ok {
+		attrs = append(attrs, tracing.UserID.String(userID))
+	}
+
+	return tracing.Start(ctx, "SessionRepository.Perform", trace.WithAttributes(attrs...))
+}
+
+// lock acquires l recording the time spent waiting on the span contained in ctx.
+func lock(ctx context.Context, l sync.Locker) {
+	start := time.Now()
+	l.Lock()
+	wait := time.Since(start)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(lockWaitKey.Float64(wait.Seconds()))
+	span.AddEvent("lock acquired")
+}
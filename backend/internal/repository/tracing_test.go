@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/is"
+	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/session"
+	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestPerform_tracesLockWait(t *testing.T) {
+	for _, store := range []string{config.SessionStoreMemory, config.SessionStoreEvents} {
+		t.Run(store, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+			repo, err := NewSessionRepository(config.Config{SessionStore: store})
+			expect.That(t, expect.FailNow(is.NoError(err)))
+			ctx := auth.WithUserID(context.Background(), "2")
+
+			err = repo.Perform(ctx, "1", func(context.Context, bool, session.Session) (session.Session, error) {
+				return session.Session{ID: "1", OwnerID: "2"}, nil
+			})
+			expect.That(t, is.NoError(err))
+
+			locked, release := make(chan struct{}), make(chan struct{})
+			var wg sync.WaitGroup
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+				repo.Perform(ctx, "1", func(context.Context, bool, session.Session) (session.Session, error) {
+					close(locked)
+					<-release
+					return session.Session{}, usecase.NoSave
+				})
+			}()
+
+			<-locked
+
+			go func() {
+				defer wg.Done()
+				repo.Perform(ctx, "1", func(context.Context, bool, session.Session) (session.Session, error) {
+					return session.Session{}, usecase.NoSave
+				})
+			}()
+
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			spans := exporter.GetSpans()
+			expect.That(t, is.EqualTo(len(spans), 3))
+
+			var maxWait float64
+			for _, s := range spans {
+				expect.That(t,
+					is.EqualTo(s.Name, "SessionRepository.Perform"),
+					is.EqualTo(attributeOf(s.Attributes, "session.id").AsString(), "1"),
+					is.EqualTo(attributeOf(s.Attributes, "user.id").AsString(), "2"),
+				)
+				maxWait = max(maxWait, attributeOf(s.Attributes, lockWaitKey).AsFloat64())
+			}
+
+			expect.That(t, is.EqualTo(maxWait >= 0.01, true))
+		})
+	}
+}
+
+func attributeOf(attrs []attribute.KeyValue, key attribute.Key) attribute.Value {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return attribute.Value{}
+}
@@ -3,15 +3,27 @@ package internal
 import (
 	"context"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/halimath/fate-core-remote-table/backend/internal/auth"
 	"github.com/halimath/fate-core-remote-table/backend/internal/domain/usecase"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/certs"
 	"github.com/halimath/fate-core-remote-table/backend/internal/infra/config"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/health"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/metrics"
+	"github.com/halimath/fate-core-remote-table/backend/internal/infra/tracing"
 	"github.com/halimath/fate-core-remote-table/backend/internal/ingress"
+	"github.com/halimath/fate-core-remote-table/backend/internal/janitor"
 	"github.com/halimath/fate-core-remote-table/backend/internal/repository"
 	"github.com/halimath/kvlog"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 var (
@@ -19,8 +31,11 @@ var (
 	Commit  string = "local"
 )
 
-func RunService(ctx context.Context) int {
-	cfg := config.Provide(ctx)
+func RunService(ctx context.Context, cfg config.Config) (exitCode int) {
+	// Cancel ctx on return so background processes also stop when the http server fails to start.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	if cfg.DevMode {
 		kvlog.L = kvlog.New(kvlog.NewSyncHandler(os.Stdout, kvlog.ConsoleFormatter()))
 	} else {
@@ -28,51 +43,273 @@ func RunService(ctx context.Context) int {
 	}
 	kvlog.L.AddHook(kvlog.TimeHook)
 
+	// The deferred functions below run in reverse order on every return path: connections are drained,
+	// background processes stopped, the repository flushed and pending spans exported before logging the exit.
+	defer func() {
+		kvlog.L.Logs("exit", kvlog.WithKV("code", exitCode))
+	}()
+
+	shutdownTracing, err := tracing.Provide(ctx, cfg, Version)
+	if err != nil {
+		kvlog.L.Logs("failed to initialize tracing", kvlog.WithErr(err))
+		return 1
+	}
+	defer func() {
+		tracingCtx, cancelTracing := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancelTracing()
+
+		if err := shutdownTracing(tracingCtx); err != nil {
+			kvlog.L.Logs("failed to export pending spans", kvlog.WithErr(err))
+		}
+	}()
+
+	var certReloader *certs.Reloader
+	if cfg.TLSEnabled() {
+		certReloader, err = certs.NewReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			kvlog.L.Logs("failed to load tls certificate", kvlog.WithErr(err))
+			return 1
+		}
+	}
+
 	tokenHandler := auth.Provide(cfg)
 
-	sessionRepo := repository.NewSessionRepository(cfg)
-	createSession := usecase.ProvideCreateSession(sessionRepo)
-	loadSession := usecase.ProvideLoadSession(sessionRepo)
-	joinSession := usecase.ProvideJoinSession(sessionRepo)
-	createAspect := usecase.ProvideCreateAspect(sessionRepo)
-	createCharacterAspect := usecase.ProvideCreateCharacterAspect(sessionRepo)
-	deleteAspect := usecase.ProvideDeleteAspect(sessionRepo)
-	updateFatePoints := usecase.ProvideUpdateFatePoints(sessionRepo)
+	var probes health.Registry
 
-	mux := ingress.Provide(cfg, kvlog.L, Version, Commit, tokenHandler, createSession,
-		loadSession, joinSession, createAspect, createCharacterAspect,
-		deleteAspect, updateFatePoints)
+	sessionRepo, err := repository.NewSessionRepository(cfg)
+	if err != nil {
+		kvlog.L.Logs("failed to open session repository", kvlog.WithErr(err))
+		return 1
+	}
+	if closer, ok := sessionRepo.(io.Closer); ok {
+		defer closer.Close()
+	}
+	defer func() {
+		flusher, ok := sessionRepo.(repository.Flusher)
+		if !ok {
+			return
+		}
+
+		flushCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+
+		if err := flusher.Flush(flushCtx); err != nil {
+			kvlog.L.Logs("failed to flush repository", kvlog.WithErr(err))
+			exitCode = 1
+		}
+	}()
+	probes.AddReadinessCheck("repository", repository.ReachabilityCheck(sessionRepo))
+	metrics.Default.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "fate_table_repository_sessions",
+		Help: "Number of sessions stored in the repository",
+	}, repository.SizeGauge(sessionRepo))
+
+	sessionJanitor := janitor.Provide(cfg, kvlog.L, sessionRepo)
+	probes.AddLivenessCheck("janitor", sessionJanitor.Check)
+
+	var background sync.WaitGroup
+	defer func() {
+		cancel()
+		background.Wait()
+	}()
+
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		sessionJanitor.Run(ctx)
+	}()
+
+	quotas := usecase.Quotas{
+		MaxSessionsPerUser:      cfg.MaxSessionsPerUser,
+		MaxCharactersPerSession: cfg.MaxCharactersPerSession,
+		MaxAspectsPerSession:    cfg.MaxAspectsPerSession,
+		MaxNameLength:           cfg.MaxNameLength,
+	}
+
+	// chain applies the concerns shared by all use cases. Panics are recovered inside of logging, metrics and
+	// tracing so they are reported like any other failure.
+	chain := usecase.Chain{
+		usecase.Log,
+		usecase.Metrics,
+		usecase.Tracing,
+		usecase.Recover,
+		usecase.Timeout(cfg.UseCaseTimeout),
+		usecase.Authenticated,
+		usecase.Validate,
+	}
+
+	createSession := usecase.Decorate(chain, "CreateSession", usecase.ProvideCreateSession(sessionRepo, quotas))
+	listSessions := usecase.Decorate(chain, "ListSessions", usecase.ProvideListSessions(sessionRepo))
+	loadSession := usecase.Decorate(chain, "LoadSession", usecase.ProvideLoadSession(sessionRepo))
+	updateSession := usecase.DecorateNoRet(chain, "UpdateSession", usecase.ProvideUpdateSession(sessionRepo, quotas))
+	archiveSession := usecase.DecorateNoRet(chain, "ArchiveSession", usecase.ProvideArchiveSession(sessionRepo))
+	deleteSession := usecase.DecorateNoRet(chain, "DeleteSession", usecase.ProvideDeleteSession(sessionRepo))
+	undoSession := usecase.DecorateNoRet(chain, "UndoSession", usecase.ProvideUndoSession(sessionRepo))
+	redoSession := usecase.DecorateNoRet(chain, "RedoSession", usecase.ProvideRedoSession(sessionRepo))
+	listSessionEvents := usecase.Decorate(chain, "ListSessionEvents", usecase.ProvideListSessionEvents(sessionRepo))
+	loadSessionAt := usecase.Decorate(chain, "LoadSessionAt", usecase.ProvideLoadSessionAt(sessionRepo))
+	joinSession := usecase.Decorate(chain, "JoinSession", usecase.ProvideJoinSession(sessionRepo, quotas))
+	spectateSession := usecase.DecorateNoRet(chain, "SpectateSession", usecase.ProvideSpectateSession(sessionRepo))
+	createInvite := usecase.Decorate(chain, "CreateInvite", usecase.ProvideCreateInvite(sessionRepo))
+	listInvites := usecase.Decorate(chain, "ListInvites", usecase.ProvideListInvites(sessionRepo))
+	revokeInvite := usecase.DecorateNoRet(chain, "RevokeInvite", usecase.ProvideRevokeInvite(sessionRepo))
+	kickPlayer := usecase.DecorateNoRet(chain, "KickPlayer", usecase.ProvideKickPlayer(sessionRepo))
+	listBans := usecase.Decorate(chain, "ListBans", usecase.ProvideListBans(sessionRepo))
+	liftBan := usecase.DecorateNoRet(chain, "LiftBan", usecase.ProvideLiftBan(sessionRepo))
+	setMemberRole := usecase.DecorateNoRet(chain, "SetMemberRole", usecase.ProvideSetMemberRole(sessionRepo))
+	createAspect := usecase.Decorate(chain, "CreateAspect", usecase.ProvideCreateAspect(sessionRepo, quotas))
+	createCharacterAspect := usecase.Decorate(chain, "CreateCharacterAspect", usecase.ProvideCreateCharacterAspect(sessionRepo, quotas))
+	deleteAspect := usecase.DecorateNoRet(chain, "DeleteAspect", usecase.ProvideDeleteAspect(sessionRepo))
+	setAspectVisibility := usecase.DecorateNoRet(chain, "SetAspectVisibility", usecase.ProvideSetAspectVisibility(sessionRepo))
+	setCharacterVisibility := usecase.DecorateNoRet(chain, "SetCharacterVisibility", usecase.ProvideSetCharacterVisibility(sessionRepo))
+	updateFatePoints := usecase.DecorateNoRet(chain, "UpdateFatePoints", usecase.ProvideUpdateFatePoints(sessionRepo))
+
+	mux, err := ingress.Provide(cfg, kvlog.L, Version, Commit, &probes, tokenHandler, createSession,
+		listSessions, loadSession, updateSession, archiveSession, deleteSession, undoSession, redoSession, listSessionEvents, loadSessionAt, joinSession, spectateSession, createInvite, listInvites, revokeInvite,
+		kickPlayer, listBans, liftBan, setMemberRole, createAspect, createCharacterAspect,
+		deleteAspect, setAspectVisibility, setCharacterVisibility, updateFatePoints)
+	if err != nil {
+		kvlog.L.Logs("failed to initialize http handler", kvlog.WithErr(err))
+		return 1
+	}
 
 	httpServer := http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.HTTPPort),
 		Handler: mux,
 	}
 
-	kvlog.L.Logs("startup", kvlog.WithKV("version", Version), kvlog.WithKV("commit", Commit))
+	if certReloader != nil {
+		// http.Server negotiates HTTP/2 when serving TLS.
+		httpServer.TLSConfig = certReloader.ServerConfig()
 
-	termChan := make(chan int, 1)
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			reloadOnHangup(ctx, certReloader)
+		}()
+	}
 
-	go func() {
-		kvlog.L.Logs("http listen", kvlog.WithKV("addr", ":8080"))
-		err := httpServer.ListenAndServe()
-		if err != http.ErrServerClosed {
-			kvlog.L.Logs("http server failed to start", kvlog.WithErr(err))
-			termChan <- 1
-			return
+	var redirectServer *http.Server
+	if cfg.HTTPRedirectPort > 0 {
+		redirectServer = &http.Server{
+			Addr:    fmt.Sprintf(":%d", cfg.HTTPRedirectPort),
+			Handler: ingress.RedirectToHTTPS(cfg.HTTPPort),
 		}
+	}
 
-		termChan <- 0
-	}()
+	var adminServer *http.Server
+	if cfg.MetricsPort > 0 {
+		adminServer = &http.Server{
+			Addr:    fmt.Sprintf(":%d", cfg.MetricsPort),
+			Handler: ingress.ProvideAdmin(),
+		}
+	}
 
+	drained := make(chan struct{})
 	go func() {
 		<-ctx.Done()
-		kvlog.L.Logs("context done; shutting done")
-		httpServer.Close()
+		defer close(drained)
+
+		probes.Drain()
+		kvlog.L.Logs("context done; draining connections", kvlog.WithKV("delay", cfg.ShutdownDelay),
+			kvlog.WithKV("timeout", cfg.ShutdownTimeout))
+		time.Sleep(cfg.ShutdownDelay)
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+
+		if redirectServer != nil {
+			if err := redirectServer.Shutdown(shutdownCtx); err != nil {
+				redirectServer.Close()
+			}
+		}
+
+		if adminServer != nil {
+			if err := adminServer.Shutdown(shutdownCtx); err != nil {
+				adminServer.Close()
+			}
+		}
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			kvlog.L.Logs("failed to drain connections; closing", kvlog.WithErr(err))
+			httpServer.Close()
+		}
 	}()
 
-	exitCode := <-termChan
-	close(termChan)
+	// Wait for in-flight requests to complete before stopping background processes and flushing the
+	// repository, even if a listener failed to start.
+	defer func() {
+		cancel()
+		<-drained
+	}()
+
+	if redirectServer != nil {
+		// Listen before serving so that failing to bind the port stops the service right away.
+		ln, err := net.Listen("tcp", redirectServer.Addr)
+		if err != nil {
+			kvlog.L.Logs("http redirect server failed to start", kvlog.WithErr(err))
+			return 1
+		}
+
+		go func() {
+			kvlog.L.Logs("http redirect listen", kvlog.WithKV("addr", redirectServer.Addr))
+			if err := redirectServer.Serve(ln); err != http.ErrServerClosed {
+				kvlog.L.Logs("http redirect server failed", kvlog.WithErr(err))
+			}
+		}()
+	}
 
-	kvlog.L.Logs("exit", kvlog.WithKV("code", exitCode))
-	return exitCode
+	if adminServer != nil {
+		ln, err := net.Listen("tcp", adminServer.Addr)
+		if err != nil {
+			kvlog.L.Logs("admin server failed to start", kvlog.WithErr(err))
+			return 1
+		}
+
+		go func() {
+			kvlog.L.Logs("admin listen", kvlog.WithKV("addr", adminServer.Addr))
+			if err := adminServer.Serve(ln); err != http.ErrServerClosed {
+				kvlog.L.Logs("admin server failed", kvlog.WithErr(err))
+			}
+		}()
+	}
+
+	kvlog.L.Logs("startup", kvlog.WithKV("version", Version), kvlog.WithKV("commit", Commit))
+	kvlog.L.Logs("http listen", kvlog.WithKV("addr", httpServer.Addr), kvlog.WithKV("tls", certReloader != nil))
+
+	if certReloader != nil {
+		err = httpServer.ListenAndServeTLS("", "")
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+
+	// ListenAndServe returns as soon as Shutdown has been called; the deferred functions wait for in-flight
+	// requests to complete.
+	if err != http.ErrServerClosed {
+		kvlog.L.Logs("http server failed to start", kvlog.WithErr(err))
+		return 1
+	}
+
+	return 0
+}
+
+// reloadOnHangup reloads the TLS certificate each time the process receives SIGHUP until ctx is done. The
+// previous certificate stays in use if reloading fails.
+func reloadOnHangup(ctx context.Context, r *certs.Reloader) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if err := r.Reload(); err != nil {
+				kvlog.L.Logs("failed to reload tls certificate", kvlog.WithErr(err))
+				continue
+			}
+			kvlog.L.Logs("tls certificate reloaded")
+		}
+	}
 }
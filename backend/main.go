@@ -6,7 +6,7 @@ import (
 	"os/signal"
 	"syscall"
 
-	"github.com/halimath/fate-core-remote-table/backend/internal"
+	"github.com/halimath/fate-core-remote-table/backend/internal/cli"
 	"github.com/halimath/kvlog"
 
 	_ "time/tzdata"
@@ -24,5 +24,5 @@ func main() {
 		cancel()
 	}()
 
-	os.Exit(internal.RunService(ctx))
+	os.Exit(cli.Run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
 }
@@ -46,6 +46,9 @@ class GameMasterScene(Scene):
     def join_session_link(self):
         return self._page.locator('[data-testid="join-session-link"]')
 
+    def invite_link_input(self):
+        return self._page.locator('input[data-testid="invite-link"]')
+
     def add_aspect_btn(self):
         return self._page.locator('[data-testid="add-aspect"]')
 
@@ -92,5 +95,8 @@ class JoinSessionModal(Modal):
     def session_id_input(self) -> Locator:
         return self.locator.locator('input[data-testid="session-id"]')
 
+    def invite_code_input(self) -> Locator:
+        return self.locator.locator('input[data-testid="invite-code"]')
+
     def player_name_input(self) -> Locator:
         return self.locator.locator('input[data-testid="player-name"]')
@@ -60,14 +60,20 @@ def test_full_game_scenario(browser: Browser, base_url: str):
         session_id = gm_page.url.split('/')[-1]
 
         #########################################
-        # Player One -> Visit HomePage, join started session
-        player_one_index_scene = IndexScene(player_one_page)
-        player_one_index_scene.visit_page()
-        player_one_index_scene.join_session_btn().click()
+        # GM -> Create an invite and share the join link
+        gm_scene.join_session_link().click()
+        expect(gm_scene.invite_link_input()).to_be_visible(timeout=timeout)
+        invite_link = gm_scene.invite_link_input().input_value()
+        assert f"/join/{session_id}/" in invite_link
+
+        #########################################
+        # Player One -> Follow the join link, join started session
+        player_one_page.goto(invite_link)
 
         join_session_modal = JoinSessionModal(player_one_page)
         expect(join_session_modal.locator).to_be_visible()
-        join_session_modal.session_id_input().fill(session_id)
+        expect(join_session_modal.session_id_input()).to_have_value(session_id)
+        expect(join_session_modal.invite_code_input()).not_to_have_value("")
         join_session_modal.player_name_input().fill("Player One")
         join_session_modal.ok_btn().click()
 